@@ -36,7 +36,10 @@ func main() {
 		[]database.TeamName{},
 		[]database.TeamSeason{},
 		[]database.TeamWeekResult{},
+		[]database.ConferenceWeekResult{},
 		[]database.Game{},
+		[]database.EloHistory{},
+		[]database.SeasonSimulation{},
 		[]database.TeamGameStats{},
 		[]database.PassingStats{},
 		[]database.RushingStats{},
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"gorm.io/gorm"
 
+	"github.com/robby-barton/stats-go/internal/backtest"
 	"github.com/robby-barton/stats-go/internal/config"
 	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/predict"
 	"github.com/robby-barton/stats-go/internal/ranking"
 )
 
@@ -38,10 +43,12 @@ func main() {
 	rootCmd.Execute() //nolint:errcheck // cobra prints errors; exit code unused
 }
 
-func sportRankCmd(db *gorm.DB, sport string, hasFCS bool) *cobra.Command {
+func sportRankCmd(db *gorm.DB, sport string, hasDivisions bool) *cobra.Command {
 	var year, week int64
-	var top int
-	var fcs, rating bool
+	var top, resamples int
+	var unified, rating bool
+	var division string
+	var model string
 
 	use := "ncaaf"
 	short := "Calculate NCAA football rankings"
@@ -55,11 +62,17 @@ func sportRankCmd(db *gorm.DB, sport string, hasFCS bool) *cobra.Command {
 		Short: short,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			r := ranking.Ranker{
-				DB:    db,
-				Year:  year,
-				Week:  week,
-				Fcs:   fcs,
-				Sport: sport,
+				DB:        db,
+				Year:      year,
+				Week:      week,
+				Unified:   unified,
+				Sport:     sport,
+				Model:     model,
+				Resamples: resamples,
+			}
+			var err error
+			if r.Division, err = parseDivision(division); err != nil {
+				return err
 			}
 
 			start := time.Now()
@@ -88,9 +101,393 @@ func sportRankCmd(db *gorm.DB, sport string, hasFCS bool) *cobra.Command {
 	cmd.Flags().Int64VarP(&week, "week", "w", 0, "ranking week")
 	cmd.Flags().IntVarP(&top, "top", "t", 0, "print top N teams")
 	cmd.Flags().BoolVarP(&rating, "rating", "r", false, "print rating")
-	if hasFCS {
-		cmd.Flags().BoolVarP(&fcs, "fcs", "f", false, "rank FCS")
+	cmd.Flags().StringVarP(&model, "model", "m", ranking.DefaultModel,
+		"rating model ("+strings.Join(ranking.ModelNames(), ", ")+")")
+	cmd.Flags().IntVarP(&resamples, "uncertainty", "u", 0,
+		"bootstrap resamples for each team's rank range (0 disables, 200 is typical)")
+	if hasDivisions {
+		addDivisionFlag(cmd, &division)
+		cmd.Flags().BoolVar(&unified, "unified", false, "rank FBS and FCS together as all of Division I")
+		cmd.MarkFlagsMutuallyExclusive("division", "unified")
+	}
+
+	cmd.AddCommand(predictCmd(db, sport, hasDivisions), backtestCmd(db, sport, hasDivisions),
+		explainCmd(db, sport, hasDivisions), whatifCmd(db, sport, hasDivisions),
+		conferencesCmd(db, sport, hasDivisions))
+
+	return cmd
+}
+
+func predictCmd(db *gorm.DB, sport string, hasDivisions bool) *cobra.Command {
+	var year, week int64
+	var home, away string
+	var neutral bool
+	var division string
+
+	cmd := &cobra.Command{
+		Use:   "predict",
+		Short: "Predict games from current ratings",
+		Long: `Predicts the expected margin and win probability of a game from SRS ratings
+and the estimated home-field advantage. With --home and --away it predicts a
+single matchup; otherwise it predicts every stored game of the ranking week.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			r := ranking.Ranker{
+				DB:    db,
+				Year:  year,
+				Week:  week,
+				Sport: sport,
+			}
+			var err error
+			if r.Division, err = parseDivision(division); err != nil {
+				return err
+			}
+
+			p, err := predict.NewPredictor(&r)
+			if err != nil {
+				return err
+			}
+
+			var predictions []predict.Prediction
+			if home != "" {
+				homeID, err := p.Team(home)
+				if err != nil {
+					return err
+				}
+				awayID, err := p.Team(away)
+				if err != nil {
+					return err
+				}
+				prediction, err := p.Game(homeID, awayID, neutral)
+				if err != nil {
+					return err
+				}
+				predictions = append(predictions, prediction)
+			} else {
+				predictions, err = p.Week(db, sport)
+				if err != nil {
+					return err
+				}
+			}
+
+			p.PrintPredictions(predictions)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64VarP(&year, "year", "y", 0, "ratings year")
+	cmd.Flags().Int64VarP(&week, "week", "w", 0, "ratings as of the start of this week")
+	cmd.Flags().StringVar(&home, "home", "", "home team name or ID")
+	cmd.Flags().StringVar(&away, "away", "", "away team name or ID")
+	cmd.Flags().BoolVarP(&neutral, "neutral", "n", false, "neutral-site game")
+	cmd.MarkFlagsRequiredTogether("home", "away")
+	if hasDivisions {
+		addDivisionFlag(cmd, &division)
+	}
+
+	return cmd
+}
+
+func backtestCmd(db *gorm.DB, sport string, hasDivisions bool) *cobra.Command {
+	var from, to int64
+	var division string
+	b := backtest.Backtest{DB: db, Sport: sport}
+
+	cmd := &cobra.Command{
+		Use:   "backtest",
+		Short: "Score predictions against past results",
+		Long: `Replays each season from --from to --to week by week. Teams are rated with only
+the games played before each week, and that week's games are predicted from
+those ratings and scored: straight-up accuracy, mean absolute margin error,
+Brier score and log loss.
+
+Example:
+  ranker ncaaf backtest --from 2015 --to 2024 --model composite`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if from <= 0 || to <= 0 || from > to {
+				return errors.New("--from and --to must be positive and from <= to")
+			}
+			var err error
+			if b.Division, err = parseDivision(division); err != nil {
+				return err
+			}
+			if b.Model != "" {
+				if _, err := ranking.LookupModel(b.Model); err != nil {
+					return err
+				}
+			}
+
+			start := time.Now()
+			scores, err := b.Run(from, to)
+			if err != nil {
+				return err
+			}
+
+			b.PrintScores(scores)
+			fmt.Fprintf(os.Stderr, "%s\n", time.Since(start))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64VarP(&from, "from", "f", 0, "first season (inclusive)")
+	cmd.Flags().Int64VarP(&to, "to", "t", 0, "last season (inclusive)")
+	cmd.Flags().Int64VarP(&b.StartWeek, "start-week", "s", 4, "first week of each season to predict")
+	cmd.Flags().StringVarP(&b.Model, "model", "m", "",
+		"also score picks by rank from this model ("+strings.Join(ranking.ModelNames(), ", ")+")")
+	if hasDivisions {
+		addDivisionFlag(cmd, &division)
+	}
+	if err := cmd.MarkFlagRequired("from"); err != nil {
+		panic(err)
+	}
+	if err := cmd.MarkFlagRequired("to"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func explainCmd(db *gorm.DB, sport string, hasDivisions bool) *cobra.Command {
+	var year, week int64
+	var team string
+	var division string
+
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Break a team's ranking score into its parts",
+		Long: `Shows how a team's composite score is built: the weighted record, SRS and SoS
+terms, SRS at the lowest and highest margin-of-victory caps, and every game the
+team's SRS is fit to, ordered by how much it helped or hurt the score.
+
+Example:
+  ranker ncaaf explain --team Alabama -y 2024 -w 10`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			r := ranking.Ranker{
+				DB:    db,
+				Year:  year,
+				Week:  week,
+				Sport: sport,
+			}
+			var err error
+			if r.Division, err = parseDivision(division); err != nil {
+				return err
+			}
+
+			e, err := r.Explain(team)
+			if err != nil {
+				return err
+			}
+
+			r.PrintExplanation(e)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&team, "team", "", "team name or ID")
+	cmd.Flags().Int64VarP(&year, "year", "y", 0, "ranking year")
+	cmd.Flags().Int64VarP(&week, "week", "w", 0, "ranking week")
+	if hasDivisions {
+		addDivisionFlag(cmd, &division)
+	}
+	if err := cmd.MarkFlagRequired("team"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func conferencesCmd(db *gorm.DB, sport string, hasDivisions bool) *cobra.Command {
+	var year, week int64
+	var division string
+	var model string
+
+	cmd := &cobra.Command{
+		Use:   "conferences",
+		Short: "Rank conferences by the ratings of their teams",
+		Long: `Ranks the division's conferences by the mean rating of their teams, with the
+median, depth (the mean rating of the conference's lower half), its teams' best
+and worst ranks and their record against other conferences.
+
+Example:
+  ranker ncaaf conferences -y 2024 -w 10`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			r := ranking.Ranker{
+				DB:    db,
+				Year:  year,
+				Week:  week,
+				Sport: sport,
+				Model: model,
+			}
+			var err error
+			if r.Division, err = parseDivision(division); err != nil {
+				return err
+			}
+
+			teamList, err := r.CalculateRanking()
+			if err != nil {
+				return err
+			}
+			conferences, err := r.Conferences(teamList)
+			if err != nil {
+				return err
+			}
+
+			r.PrintConferences(conferences)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64VarP(&year, "year", "y", 0, "ranking year")
+	cmd.Flags().Int64VarP(&week, "week", "w", 0, "ranking week")
+	cmd.Flags().StringVarP(&model, "model", "m", ranking.DefaultModel,
+		"rating model ("+strings.Join(ranking.ModelNames(), ", ")+")")
+	if hasDivisions {
+		addDivisionFlag(cmd, &division)
+	}
+
+	return cmd
+}
+
+func whatifCmd(db *gorm.DB, sport string, hasDivisions bool) *cobra.Command {
+	var year, week int64
+	var top int
+	var division string
+	var model string
+	var games []string
+
+	cmd := &cobra.Command{
+		Use:   "whatif",
+		Short: "Rank with hypothetical results",
+		Long: `Ranks the division as if the given results were final, without storing them,
+and shows each team's rank and score before and after. A result replaces the
+two teams' stored game of the season if they have one (the next unplayed one
+first), or is added as an extra game otherwise. Teams are names or IDs; append
+":neutral" for a neutral site.
+
+Example:
+  ranker ncaaf whatif --game Alabama:Auburn:31:21 --game 2:333:10:14`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			before := ranking.Ranker{
+				DB:    db,
+				Year:  year,
+				Week:  week,
+				Sport: sport,
+				Model: model,
+			}
+			var err error
+			if before.Division, err = parseDivision(division); err != nil {
+				return err
+			}
+			beforeList, err := before.CalculateRanking()
+			if err != nil {
+				return err
+			}
+
+			var hypothetical []ranking.HypotheticalGame
+			for _, arg := range games {
+				game, err := parseHypothetical(arg, beforeList)
+				if err != nil {
+					return err
+				}
+				hypothetical = append(hypothetical, game)
+			}
+
+			after := ranking.Ranker{
+				DB:           db,
+				Year:         before.Year,
+				Week:         week,
+				Division:     before.Division,
+				Sport:        sport,
+				Model:        model,
+				Hypothetical: hypothetical,
+			}
+			afterList, err := after.CalculateRanking()
+			if err != nil {
+				return err
+			}
+
+			// sanitize input
+			if top <= 0 || top > len(afterList) {
+				top = len(afterList)
+			}
+
+			after.PrintWhatIf(beforeList, afterList, top)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&games, "game", "g", nil, "hypothetical result as home:away:homeScore:awayScore")
+	cmd.Flags().Int64VarP(&year, "year", "y", 0, "ranking year")
+	cmd.Flags().Int64VarP(&week, "week", "w", 0, "ranking week")
+	cmd.Flags().IntVarP(&top, "top", "t", 0, "print top N teams")
+	cmd.Flags().StringVarP(&model, "model", "m", ranking.DefaultModel,
+		"rating model ("+strings.Join(ranking.ModelNames(), ", ")+")")
+	if hasDivisions {
+		addDivisionFlag(cmd, &division)
+	}
+	if err := cmd.MarkFlagRequired("game"); err != nil {
+		panic(err)
 	}
 
 	return cmd
 }
+
+// addDivisionFlag adds the --division flag selecting the football division.
+func addDivisionFlag(cmd *cobra.Command, division *string) {
+	names := make([]string, 0, len(database.FootballDivisions()))
+	for _, d := range database.FootballDivisions() {
+		names = append(names, string(d))
+	}
+	cmd.Flags().StringVarP(division, "division", "d", string(database.DivisionFBS),
+		"football division ("+strings.Join(names, ", ")+")")
+}
+
+// parseDivision validates a --division value. An empty value, as for sports
+// without the flag, selects the top division.
+func parseDivision(division string) (database.Division, error) {
+	if division == "" {
+		return "", nil
+	}
+	for _, d := range database.FootballDivisions() {
+		if strings.EqualFold(division, string(d)) {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("unknown division %q", division)
+}
+
+// parseHypothetical parses home:away:homeScore:awayScore[:neutral]. Teams are
+// IDs, or names of teams in the division.
+func parseHypothetical(arg string, teams ranking.TeamList) (ranking.HypotheticalGame, error) {
+	parts := strings.Split(arg, ":")
+	if len(parts) != 4 && (len(parts) != 5 || parts[4] != "neutral") {
+		return ranking.HypotheticalGame{}, fmt.Errorf("game %q: want home:away:homeScore:awayScore[:neutral]", arg)
+	}
+
+	var ids [2]int64
+	for i, team := range parts[:2] {
+		id, err := strconv.ParseInt(team, 10, 64)
+		if err != nil {
+			if id, err = teams.Lookup(team); err != nil {
+				return ranking.HypotheticalGame{}, fmt.Errorf("game %q: %w", arg, err)
+			}
+		}
+		ids[i] = id
+	}
+
+	var scores [2]int64
+	for i, score := range parts[2:4] {
+		value, err := strconv.ParseInt(score, 10, 64)
+		if err != nil || value < 0 {
+			return ranking.HypotheticalGame{}, fmt.Errorf("game %q: invalid score %q", arg, score)
+		}
+		scores[i] = value
+	}
+
+	return ranking.HypotheticalGame{
+		HomeID:    ids[0],
+		AwayID:    ids[1],
+		HomeScore: scores[0],
+		AwayScore: scores[1],
+		Neutral:   len(parts) == 5,
+	}, nil
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/robby-barton/stats-go/internal/database"
 	"github.com/robby-barton/stats-go/internal/espn"
 	"github.com/robby-barton/stats-go/internal/logger"
+	"github.com/robby-barton/stats-go/internal/ranking"
 	"github.com/robby-barton/stats-go/internal/updater"
 )
 
@@ -40,24 +42,39 @@ func main() {
 	}
 	rootCmd.SilenceUsage = true
 
-	scheduleCmd := scheduleCommand(log, db, cfg.DeployScript)
-	ncaafCmd := sportCommand(log, db, espn.CollegeFootball)
-	ncaamCmd := sportCommand(log, db, espn.CollegeBasketball)
+	// one limiter and breaker keep both sports under a single ESPN request
+	// rate and stop them both calling a failing host
+	espnCfg := espn.ClientConfig{
+		ArchiveDir: cfg.ArchiveDir,
+		CacheDir:   cfg.CacheDir,
+		Limiter:    espn.NewLimiter(espn.DefaultRequestInterval, espn.DefaultRequestBurst),
+		Breaker:    espn.NewBreaker(log, espn.DefaultBreakerThreshold, espn.DefaultBreakerCoolDown),
+		Logger:     log,
+	}
+	scheduleCmd := scheduleCommand(log, db, cfg.DeployScript, espnCfg)
+	ncaafCmd := sportCommand(log, db, espnCfg, espn.CollegeFootball)
+	ncaamCmd := sportCommand(log, db, espnCfg, espn.CollegeBasketball)
 
 	rootCmd.AddCommand(scheduleCmd, ncaafCmd, ncaamCmd)
 
-	rootCmd.Execute() //nolint:errcheck // cobra prints errors; exit code unused
+	// SIGINT and SIGTERM cancel in-flight ESPN requests and backoff waits
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	rootCmd.ExecuteContext(ctx) //nolint:errcheck // cobra prints errors; exit code unused
 }
 
 func newUpdater(
 	log *zap.SugaredLogger,
 	db *gorm.DB,
+	espnCfg espn.ClientConfig,
 	sport espn.Sport,
 ) updater.Updater {
 	return updater.Updater{
-		DB:     db,
-		Logger: log,
-		ESPN:   espn.NewClientForSport(sport),
+		DB:         db,
+		Logger:     log,
+		ESPN:       espn.NewClientForSport(sport, espnCfg),
+		ArchiveDir: espnCfg.ArchiveDir,
 	}
 }
 
@@ -116,21 +133,32 @@ type sportSchedule struct {
 	NewSeasonCron string // season initialization
 }
 
+const (
+	// gamesJobTimeout keeps a games poll from running into the next one.
+	gamesJobTimeout = 4 * time.Minute
+	// jobTimeout bounds the other jobs and each ranking update.
+	jobTimeout = time.Hour
+)
+
 // registerJobs adds the three cron jobs for a sport to the scheduler and
-// returns a stop function that shuts down the ranking-update goroutine.
+// returns a stop function that shuts down the ranking-update goroutine. Every
+// job runs under a deadline derived from ctx, so canceling ctx stops them all.
 func (ss sportSchedule) registerJobs(
+	ctx context.Context,
 	s gocron.Scheduler,
 	log *zap.SugaredLogger,
 	u updater.Updater,
 	d *deployer,
 ) func() {
-	update := make(chan bool, 1)
+	// update carries the games stored since the last ranking; nil re-ranks
+	// the current week
+	update := make(chan []int64, 1)
 	stop := make(chan bool, 1)
 
 	go func() {
 		for {
 			select {
-			case <-update:
+			case gameIDs := <-update:
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
@@ -138,7 +166,16 @@ func (ss sportSchedule) registerJobs(
 						}
 					}()
 
-					if err := u.UpdateRecentRankings(); err != nil {
+					jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+					defer cancel()
+
+					var err error
+					if gameIDs == nil {
+						err = u.UpdateRecentRankings(jobCtx)
+					} else {
+						err = u.UpdateRankingsForGames(jobCtx, gameIDs)
+					}
+					if err != nil {
 						log.Error(err)
 						return
 					}
@@ -159,12 +196,18 @@ func (ss sportSchedule) registerJobs(
 			}
 		}()
 
-		addedGames, err := u.UpdateCurrentWeek()
+		jobCtx, cancel := context.WithTimeout(ctx, gamesJobTimeout)
+		defer cancel()
+
+		addedGames, err := u.UpdateCurrentWeek(jobCtx)
 		log.Infof("%s: added %d games: %v", ss.Name, len(addedGames), addedGames)
 		if err != nil {
 			log.Error(err)
-		} else if len(addedGames) > 0 {
-			update <- true
+		}
+		// games stored before an error or timeout are never polled again,
+		// so they have to be ranked now
+		if len(addedGames) > 0 {
+			update <- addedGames
 		}
 	})); err != nil {
 		panic(err)
@@ -178,7 +221,10 @@ func (ss sportSchedule) registerJobs(
 			}
 		}()
 
-		addedTeams, err := u.UpdateTeamInfo()
+		jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+		defer cancel()
+
+		addedTeams, err := u.UpdateTeamInfo(jobCtx)
 		if err != nil {
 			log.Error(err)
 			return
@@ -197,12 +243,15 @@ func (ss sportSchedule) registerJobs(
 			}
 		}()
 
-		addedSeasons, err := u.UpdateTeamSeasons(false)
+		jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+		defer cancel()
+
+		addedSeasons, err := u.UpdateTeamSeasons(jobCtx, false)
 		log.Infof("%s: added %d seasons", ss.Name, addedSeasons)
 		if err != nil {
 			log.Error(err)
 		} else if addedSeasons > 0 {
-			update <- true
+			update <- nil
 		}
 	})); err != nil {
 		panic(err)
@@ -215,11 +264,13 @@ func scheduleCommand(
 	log *zap.SugaredLogger,
 	db *gorm.DB,
 	deployScript string,
+	espnCfg espn.ClientConfig,
 ) *cobra.Command {
 	return &cobra.Command{
 		Use:   "schedule",
 		Short: "Run the scheduled updater for all sports",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(c *cobra.Command, _ []string) error {
+			ctx := c.Context()
 			s, err := gocron.NewScheduler(gocron.WithLocation(time.Local))
 			if err != nil {
 				panic(err)
@@ -253,17 +304,15 @@ func scheduleCommand(
 
 			var stopFuncs []func()
 			for _, sp := range sports {
-				u := newUpdater(log, db, sp.sport)
-				stopFn := sp.schedule.registerJobs(s, log, u, d)
+				u := newUpdater(log, db, espnCfg, sp.sport)
+				stopFn := sp.schedule.registerJobs(ctx, s, log, u, d)
 				stopFuncs = append(stopFuncs, stopFn)
 			}
 
 			s.Start()
 
-			end := make(chan os.Signal, 1)
-			signal.Notify(end, syscall.SIGINT, syscall.SIGTERM)
-
-			<-end
+			// canceled on SIGINT or SIGTERM, which also cancels running jobs
+			<-ctx.Done()
 			if err := s.Shutdown(); err != nil {
 				log.Error(err)
 			}
@@ -280,9 +329,10 @@ func scheduleCommand(
 func sportCommand(
 	log *zap.SugaredLogger,
 	db *gorm.DB,
+	espnCfg espn.ClientConfig,
 	sport espn.Sport,
 ) *cobra.Command {
-	u := newUpdater(log, db, sport)
+	u := newUpdater(log, db, espnCfg, sport)
 
 	use := "ncaaf"
 	short := "NCAA football one-shot commands"
@@ -296,18 +346,23 @@ func sportCommand(
 		Short: short,
 	}
 
-	var gamesAll bool
+	var gamesAll, gamesRank bool
 	var gamesSingle int64
 	var gamesYear int64
 	gamesCmd := &cobra.Command{
 		Use:   "games",
 		Short: "One-time game update",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(c *cobra.Command, _ []string) error {
 			if gamesSingle > 0 {
-				if err := u.UpdateSingleGame(gamesSingle); err != nil {
+				if err := u.UpdateSingleGame(c.Context(), gamesSingle); err != nil {
 					log.Error(err)
-				} else {
-					log.Infof("Game %d updated", gamesSingle)
+					return nil
+				}
+				log.Infof("Game %d updated", gamesSingle)
+				if gamesRank {
+					if err := u.UpdateRankingsForGames(c.Context(), []int64{gamesSingle}); err != nil {
+						log.Error(err)
+					}
 				}
 				return nil
 			}
@@ -316,36 +371,61 @@ func sportCommand(
 			var err error
 			switch {
 			case gamesYear > 0:
-				addedGames, err = u.UpdateGamesForYear(gamesYear)
+				addedGames, err = u.UpdateGamesForYear(c.Context(), gamesYear)
 			case gamesAll:
 				year, _, _ := time.Now().Date()
-				addedGames, err = u.UpdateGamesForYear(int64(year))
+				addedGames, err = u.UpdateGamesForYear(c.Context(), int64(year))
 			default:
-				addedGames, err = u.UpdateCurrentWeek()
+				addedGames, err = u.UpdateCurrentWeek(c.Context())
 			}
 			if err != nil {
 				log.Error(err)
-			} else {
-				log.Infof("Added %d games: %v", len(addedGames), addedGames)
+				return nil
+			}
+			log.Infof("Added %d games: %v", len(addedGames), addedGames)
+			if gamesRank {
+				if err := u.UpdateRankingsForGames(c.Context(), addedGames); err != nil {
+					log.Error(err)
+				}
 			}
 			return nil
 		},
 	}
 	gamesCmd.Flags().BoolVar(&gamesAll, "all", false, "update all games for the current year")
+	gamesCmd.Flags().BoolVar(&gamesRank, "rank", false, "re-rank the weeks the updated games count toward")
 	gamesCmd.Flags().Int64Var(&gamesSingle, "single", 0, "force update one game by ID")
 	gamesCmd.Flags().Int64Var(&gamesYear, "year", 0, "update all games for a specific year")
 	gamesCmd.MarkFlagsMutuallyExclusive("all", "single", "year")
 
-	var rankingAll bool
+	var rankingAll, rankingUnified bool
+	var rankingModels []string
+	var rankingResamples, rankingWorkers int
+	var rankingFrom, rankingTo int64
 	rankingCmd := &cobra.Command{
 		Use:   "ranking",
 		Short: "One-time ranking update",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(c *cobra.Command, _ []string) error {
+			for _, model := range rankingModels {
+				if _, err := ranking.LookupModel(model); err != nil {
+					return err
+				}
+			}
+			if !rankingAll && (rankingFrom != 0 || rankingTo != 0) {
+				return errors.New("--from and --to require --all")
+			}
+			if rankingTo != 0 && rankingFrom > rankingTo {
+				return errors.New("--from must be <= --to")
+			}
+			u.Models = rankingModels
+			u.Resamples = rankingResamples
+			u.Workers = rankingWorkers
+			u.Unified = rankingUnified
+
 			var err error
 			if rankingAll {
-				err = u.UpdateAllRankings()
+				err = u.UpdateAllRankings(c.Context(), rankingFrom, rankingTo)
 			} else {
-				err = u.UpdateRecentRankings()
+				err = u.UpdateRecentRankings(c.Context())
 			}
 			if err != nil {
 				log.Error(err)
@@ -354,12 +434,23 @@ func sportCommand(
 		},
 	}
 	rankingCmd.Flags().BoolVar(&rankingAll, "all", false, "update all rankings")
+	rankingCmd.Flags().Int64Var(&rankingFrom, "from", 0, "with --all, first season to rank (default: earliest)")
+	rankingCmd.Flags().Int64Var(&rankingTo, "to", 0, "with --all, last season to rank (default: latest)")
+	rankingCmd.Flags().IntVar(&rankingWorkers, "workers", 0, "with --all, weeks ranked at once (default: one per CPU)")
+	rankingCmd.Flags().StringSliceVar(&rankingModels, "model", []string{ranking.DefaultModel},
+		"rating models to store ("+strings.Join(ranking.ModelNames(), ", ")+")")
+	rankingCmd.Flags().IntVar(&rankingResamples, "uncertainty", 0,
+		"bootstrap resamples for composite rank ranges (0 disables)")
+	if sport == espn.CollegeFootball {
+		rankingCmd.Flags().BoolVar(&rankingUnified, "unified", false,
+			"rank FBS and FCS together, storing division and overall ranks")
+	}
 
 	teamsCmd := &cobra.Command{
 		Use:   "teams",
 		Short: "Update team info",
-		RunE: func(_ *cobra.Command, _ []string) error {
-			addedTeams, err := u.UpdateTeamInfo()
+		RunE: func(c *cobra.Command, _ []string) error {
+			addedTeams, err := u.UpdateTeamInfo(c.Context())
 			if err != nil {
 				log.Error(err)
 			} else {
@@ -373,15 +464,15 @@ func sportCommand(
 	seasonCmd := &cobra.Command{
 		Use:   "season",
 		Short: "Update season info",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(c *cobra.Command, _ []string) error {
 			var (
 				addedSeasons int
 				err          error
 			)
 			if seasonYear > 0 {
-				addedSeasons, err = u.UpdateTeamSeasonsForYear(seasonYear, true)
+				addedSeasons, err = u.UpdateTeamSeasonsForYear(c.Context(), seasonYear, true)
 			} else {
-				addedSeasons, err = u.UpdateTeamSeasons(true)
+				addedSeasons, err = u.UpdateTeamSeasons(c.Context(), true)
 			}
 			if err != nil {
 				log.Error(err)
@@ -393,37 +484,116 @@ func sportCommand(
 	}
 	seasonCmd.Flags().Int64Var(&seasonYear, "year", 0, "update seasons for a specific year (default: current season)")
 
+	eloCmd := &cobra.Command{
+		Use:   "elo",
+		Short: "Rebuild the Elo rating history from all stored games",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			applied, err := u.RebuildEloHistory()
+			if err != nil {
+				log.Error(err)
+			} else {
+				log.Infof("Applied %d games to Elo history", applied)
+			}
+			return nil
+		},
+	}
+
+	var simYear, simWeek, simSeed int64
+	var simRuns, simTop int
+	simulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate the rest of the season and store each team's odds",
+		Long: `Plays out the remaining regular season --runs times from current ratings and
+stores each team's distribution of final wins, chance of winning its conference
+and chance of finishing in the top --top of the final ranking. Results are
+deterministic for a given --seed.
+
+Example:
+  updater ncaaf simulate --year 2024 --week 8 --runs 10000`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if simRuns <= 0 || simTop <= 0 {
+				return errors.New("--runs and --top must be positive")
+			}
+			simulated, err := u.UpdateSeasonSimulations(simYear, simWeek, simRuns, simTop, simSeed)
+			if err != nil {
+				log.Error(err)
+			} else {
+				log.Infof("Simulated %d teams", simulated)
+			}
+			return nil
+		},
+	}
+	simulateCmd.Flags().Int64Var(&simYear, "year", 0, "season to simulate (default: current season)")
+	simulateCmd.Flags().Int64Var(&simWeek, "week", 0, "simulate from the start of this week (default: next week)")
+	simulateCmd.Flags().IntVar(&simRuns, "runs", 10000, "number of simulated seasons")
+	simulateCmd.Flags().IntVar(&simTop, "top", 25, "report the chance of finishing in the top N")
+	simulateCmd.Flags().Int64Var(&simSeed, "seed", 1, "random seed")
+
+	var reparseYear int64
+	var reparseRank bool
+	reparseCmd := &cobra.Command{
+		Use:   "reparse",
+		Short: "Rebuild a season's games from the ESPN response archive",
+		Long: `Re-parses the archived box score of every final game in --year and replaces
+the stored game and stat rows, without contacting ESPN. Use after fixing a
+parser bug. Requires ESPN_ARCHIVE_DIR; games that were never archived are
+left as stored.
+
+Example:
+  updater ncaaf reparse --year 2024 --rank`,
+		RunE: func(c *cobra.Command, _ []string) error {
+			gameIDs, err := u.ReparseGamesForYear(c.Context(), reparseYear)
+			if err != nil {
+				log.Error(err)
+				return nil
+			}
+			log.Infof("Reparsed %d games", len(gameIDs))
+			if reparseRank {
+				if err := u.UpdateRankingsForGames(c.Context(), gameIDs); err != nil {
+					log.Error(err)
+				}
+			}
+			return nil
+		},
+	}
+	reparseCmd.Flags().Int64Var(&reparseYear, "year", 0, "season to reparse")
+	reparseCmd.Flags().BoolVar(&reparseRank, "rank", false, "re-rank the weeks the reparsed games count toward")
+	if err := reparseCmd.MarkFlagRequired("year"); err != nil {
+		panic(err)
+	}
+
 	var backfillFrom, backfillTo int64
 	backfillCmd := &cobra.Command{
 		Use:   "backfill",
 		Short: "Backfill games, seasons, and rankings for a range of years",
 		Long: `Fetches team seasons and games from ESPN for each year in [from, to],
-then recomputes all rankings. Existing records are skipped unless already absent.
+then recomputes the rankings from --from on. Existing records are skipped unless already absent.
 
 Example:
   updater ncaam backfill --from 2021 --to 2025`,
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(c *cobra.Command, _ []string) error {
 			if backfillFrom <= 0 || backfillTo <= 0 || backfillFrom > backfillTo {
-				return fmt.Errorf("--from and --to must be positive and from <= to")
+				return errors.New("--from and --to must be positive and from <= to")
 			}
 			for year := backfillFrom; year <= backfillTo; year++ {
 				log.Infof("Backfilling %s year %d...", use, year)
 
-				n, err := u.UpdateTeamSeasonsForYear(year, false)
+				n, err := u.UpdateTeamSeasonsForYear(c.Context(), year, false)
 				if err != nil {
 					return fmt.Errorf("team seasons %d: %w", year, err)
 				}
 				log.Infof("  seasons: %d teams", n)
 
-				addedGames, err := u.UpdateGamesForYear(year)
+				addedGames, err := u.UpdateGamesForYear(c.Context(), year)
 				if err != nil {
 					return fmt.Errorf("games %d: %w", year, err)
 				}
 				log.Infof("  games: %d added", len(addedGames))
 			}
 
-			log.Infof("Recomputing all %s rankings...", use)
-			if err := u.UpdateAllRankings(); err != nil {
+			// earlier seasons' rankings never read later games
+			log.Infof("Recomputing %s rankings from %d...", use, backfillFrom)
+			if err := u.UpdateAllRankings(c.Context(), backfillFrom, 0); err != nil {
 				return fmt.Errorf("rankings: %w", err)
 			}
 			log.Infof("Backfill complete (%s %d–%d)", use, backfillFrom, backfillTo)
@@ -439,7 +609,7 @@ Example:
 		panic(err)
 	}
 
-	cmd.AddCommand(gamesCmd, rankingCmd, teamsCmd, seasonCmd, backfillCmd)
+	cmd.AddCommand(gamesCmd, rankingCmd, teamsCmd, seasonCmd, eloCmd, simulateCmd, reparseCmd, backfillCmd)
 
 	return cmd
 }
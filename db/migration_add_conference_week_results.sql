@@ -0,0 +1,28 @@
+-- Migration: Add conference_week_results table holding conference strength per ranking week.
+-- Run this against an existing PostgreSQL database before deploying the conference strength code.
+-- Rows are written alongside team_week_results; run `updater <sport> ranking --all` to fill past weeks.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS conference_week_results (
+    conf text NOT NULL,
+    year integer NOT NULL,
+    week integer NOT NULL,
+    postseason integer DEFAULT 0 NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    model text DEFAULT 'composite' NOT NULL,
+    fbs boolean DEFAULT false NOT NULL,
+    teams integer DEFAULT 0,
+    rank integer DEFAULT 0,
+    mean_raw real DEFAULT 0,
+    median_raw real DEFAULT 0,
+    depth real DEFAULT 0,
+    best_rank integer DEFAULT 0,
+    worst_rank integer DEFAULT 0,
+    non_conf_wins integer DEFAULT 0,
+    non_conf_losses integer DEFAULT 0,
+    non_conf_ties integer DEFAULT 0,
+    CONSTRAINT conference_week_results_pkey PRIMARY KEY (conf, year, week, postseason, sport, model, fbs)
+);
+
+COMMIT;
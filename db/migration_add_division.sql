@@ -0,0 +1,26 @@
+-- Migration: Add a division column alongside the fbs flags, adding Division II and III.
+-- Run this against an existing PostgreSQL database before deploying the division code.
+-- Existing rows keep their division: an fbs of 1 or true becomes 'fbs', anything else 'fcs'.
+-- The fbs columns stay, written alongside division, until stats-web reads division;
+-- migration_drop_fbs.sql removes them after that.
+
+BEGIN;
+
+ALTER TABLE team_seasons ADD COLUMN IF NOT EXISTS division text DEFAULT 'fbs';
+UPDATE team_seasons SET division = CASE WHEN fbs = 1 THEN 'fbs' ELSE 'fcs' END;
+
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS division text DEFAULT 'fbs';
+UPDATE team_week_results SET division = CASE WHEN fbs THEN 'fbs' ELSE 'fcs' END;
+CREATE INDEX IF NOT EXISTS division_index ON team_week_results USING btree (division);
+
+-- fcs, dii and diii all have fbs false, so the key moves to division
+ALTER TABLE conference_week_results ADD COLUMN IF NOT EXISTS division text DEFAULT 'fbs' NOT NULL;
+UPDATE conference_week_results SET division = CASE WHEN fbs THEN 'fbs' ELSE 'fcs' END;
+ALTER TABLE conference_week_results DROP CONSTRAINT IF EXISTS conference_week_results_pkey;
+ALTER TABLE conference_week_results ADD CONSTRAINT conference_week_results_pkey
+    PRIMARY KEY (conf, year, week, postseason, sport, model, division);
+
+ALTER TABLE season_simulations ADD COLUMN IF NOT EXISTS division text DEFAULT 'fbs';
+UPDATE season_simulations SET division = CASE WHEN fbs THEN 'fbs' ELSE 'fcs' END;
+
+COMMIT;
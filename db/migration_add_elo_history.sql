@@ -0,0 +1,20 @@
+-- Migration: Add elo_history table holding every team's Elo rating before and after each game.
+-- Run this against an existing PostgreSQL database before deploying the Elo rating model code.
+-- The table is filled by the updater on its next run.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS elo_history (
+    game_id integer NOT NULL,
+    team_id integer NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    season integer DEFAULT 0,
+    start_time timestamp with time zone,
+    pre_rating double precision DEFAULT 0,
+    post_rating double precision DEFAULT 0,
+    CONSTRAINT elo_history_pkey PRIMARY KEY (game_id, team_id, sport)
+);
+
+CREATE INDEX IF NOT EXISTS elo_history_start_time_index ON elo_history (start_time);
+
+COMMIT;
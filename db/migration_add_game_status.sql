@@ -0,0 +1,13 @@
+-- Migration: Add status and venue columns so scheduled, in-progress, postponed and
+-- canceled games can be stored alongside finals.
+-- Run this against an existing PostgreSQL database before deploying the game status code.
+-- Every existing game was stored because it was final.
+
+BEGIN;
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS status text DEFAULT 'final' NOT NULL;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS venue text;
+
+CREATE INDEX IF NOT EXISTS game_status_index ON games (status);
+
+COMMIT;
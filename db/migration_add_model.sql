@@ -0,0 +1,13 @@
+-- Migration: Add model column so several rating models can store results side by side.
+-- Run this against an existing PostgreSQL database before deploying the rating model code.
+-- All existing rankings were produced by the composite model.
+
+BEGIN;
+
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS model text DEFAULT 'composite' NOT NULL;
+
+ALTER TABLE team_week_results DROP CONSTRAINT IF EXISTS team_week_result_pkey;
+ALTER TABLE team_week_results ADD CONSTRAINT team_week_result_pkey
+    PRIMARY KEY (team_id, year, week, postseason, sport, model);
+
+COMMIT;
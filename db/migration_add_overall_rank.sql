@@ -0,0 +1,9 @@
+-- Migration: Add overall_rank to team_week_results for unified FBS+FCS rankings.
+-- Run this against an existing PostgreSQL database before deploying the unified ranking code.
+-- Division-only rankings keep a zero overall_rank.
+
+BEGIN;
+
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS overall_rank integer DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Migration: Add bootstrap interval and rank range columns to team_week_results.
+-- Run this against an existing PostgreSQL database before deploying the ranking uncertainty code.
+-- Existing rankings were stored without them and keep zeros.
+
+BEGIN;
+
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS final_raw_low real DEFAULT 0;
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS final_raw_high real DEFAULT 0;
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS rank_best integer DEFAULT 0;
+ALTER TABLE team_week_results ADD COLUMN IF NOT EXISTS rank_worst integer DEFAULT 0;
+
+COMMIT;
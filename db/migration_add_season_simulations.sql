@@ -0,0 +1,25 @@
+-- Migration: Add season_simulations table holding Monte Carlo season odds per team and week.
+-- Run this against an existing PostgreSQL database before deploying the season simulation code.
+-- Rows are written by `updater <sport> simulate`.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS season_simulations (
+    team_id integer NOT NULL,
+    year integer NOT NULL,
+    week integer NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    name text,
+    conf text,
+    fbs boolean,
+    runs integer DEFAULT 0,
+    seed bigint DEFAULT 0,
+    mean_wins double precision DEFAULT 0,
+    win_dist text,
+    conf_win_prob double precision DEFAULT 0,
+    top_n integer DEFAULT 0,
+    top_n_prob double precision DEFAULT 0,
+    CONSTRAINT season_simulations_pkey PRIMARY KEY (team_id, year, week, sport)
+);
+
+COMMIT;
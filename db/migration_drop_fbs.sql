@@ -0,0 +1,20 @@
+-- Migration: Drop the fbs flags replaced by division.
+-- Do not run this until stats-web reads division instead of fbs. Deploy the
+-- code without the legacy fbs fields first; the columns default, so inserts
+-- that leave them out keep working until they are dropped.
+-- Rollback: re-add the columns and backfill them from division
+-- (fbs = division = 'fbs'); no data is lost, since division holds everything
+-- fbs did.
+
+BEGIN;
+
+ALTER TABLE team_seasons DROP COLUMN IF EXISTS fbs;
+
+DROP INDEX IF EXISTS fbs_index;
+ALTER TABLE team_week_results DROP COLUMN IF EXISTS fbs;
+
+ALTER TABLE conference_week_results DROP COLUMN IF EXISTS fbs;
+
+ALTER TABLE season_simulations DROP COLUMN IF EXISTS fbs;
+
+COMMIT;
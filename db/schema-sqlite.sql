@@ -7,6 +7,29 @@ CREATE TABLE composite (
 );
 
 
+CREATE TABLE conference_week_results (
+    conf text NOT NULL,
+    year integer NOT NULL,
+    week integer NOT NULL,
+    postseason integer DEFAULT 0 NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    model text DEFAULT 'composite' NOT NULL,
+    division text DEFAULT 'fbs' NOT NULL,
+    fbs boolean DEFAULT false NOT NULL,
+    teams integer DEFAULT 0,
+    rank integer DEFAULT 0,
+    mean_raw real DEFAULT 0,
+    median_raw real DEFAULT 0,
+    depth real DEFAULT 0,
+    best_rank integer DEFAULT 0,
+    worst_rank integer DEFAULT 0,
+    non_conf_wins integer DEFAULT 0,
+    non_conf_losses integer DEFAULT 0,
+    non_conf_ties integer DEFAULT 0,
+	PRIMARY KEY (conf, year, week, postseason, sport, model, division)
+);
+
+
 CREATE TABLE defensive_stats (
     player_id integer NOT NULL,
     team_id integer NOT NULL,
@@ -23,6 +46,22 @@ CREATE TABLE defensive_stats (
 );
 
 
+CREATE TABLE elo_history (
+    game_id integer NOT NULL,
+    team_id integer NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    season integer DEFAULT 0,
+    start_time timestamp with time zone,
+    pre_rating real DEFAULT 0,
+    post_rating real DEFAULT 0,
+	PRIMARY KEY (game_id, team_id, sport),
+	FOREIGN KEY (game_id) REFERENCES games(game_id) ON DELETE CASCADE
+);
+
+
+CREATE INDEX elo_history_start_time_index ON elo_history (start_time);
+
+
 CREATE TABLE fumble_stats (
     player_id integer NOT NULL,
     team_id integer NOT NULL,
@@ -49,6 +88,8 @@ CREATE TABLE games (
     start_time timestamp with time zone,
     home_score integer DEFAULT 0,
     away_score integer DEFAULT 0,
+    status text DEFAULT 'final' NOT NULL,
+    venue text,
 	PRIMARY KEY (game_id),
 	FOREIGN KEY (game_id) REFERENCES games(game_id) ON DELETE CASCADE
 );
@@ -187,6 +228,26 @@ CREATE TABLE rushing_stats (
 );
 
 
+CREATE TABLE season_simulations (
+    team_id integer NOT NULL,
+    year integer NOT NULL,
+    week integer NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    name text,
+    conf text,
+    division text DEFAULT 'fbs',
+    fbs boolean,
+    runs integer DEFAULT 0,
+    seed integer DEFAULT 0,
+    mean_wins real DEFAULT 0,
+    win_dist text,
+    conf_win_prob real DEFAULT 0,
+    top_n integer DEFAULT 0,
+    top_n_prob real DEFAULT 0,
+	PRIMARY KEY (team_id, year, week, sport)
+);
+
+
 CREATE TABLE team_game_stats (
     game_id integer NOT NULL,
     team_id integer NOT NULL,
@@ -237,6 +298,7 @@ CREATE TABLE team_seasons (
     team_id integer NOT NULL,
     year integer NOT NULL,
     sport text DEFAULT 'ncaaf',
+    division text DEFAULT 'fbs',
     fbs integer DEFAULT 0,
     power_five integer DEFAULT 0,
     conf text,
@@ -257,15 +319,25 @@ CREATE TABLE team_week_results (
     srs_rank integer DEFAULT 0,
     sos_rank integer DEFAULT 0,
     sov_rank integer DEFAULT 0,
+    division text DEFAULT 'fbs',
     fbs boolean,
     name text,
     conf text,
     sol_rank integer DEFAULT 0,
     ties integer DEFAULT 0,
-	PRIMARY KEY (team_id, year, week, postseason, sport)
+    model text DEFAULT 'composite' NOT NULL,
+    final_raw_low real DEFAULT 0,
+    final_raw_high real DEFAULT 0,
+    rank_best integer DEFAULT 0,
+    rank_worst integer DEFAULT 0,
+    overall_rank integer DEFAULT 0,
+	PRIMARY KEY (team_id, year, week, postseason, sport, model)
 );
 
 
+CREATE INDEX division_index ON team_week_results (division);
+
+
 CREATE INDEX fbs_index ON team_week_results (fbs);
 
 
@@ -284,6 +356,9 @@ CREATE INDEX game_season_index ON games (season);
 CREATE INDEX game_start_time_index ON games (start_time);
 
 
+CREATE INDEX game_status_index ON games (status);
+
+
 CREATE INDEX game_week_index ON games (week);
 
 
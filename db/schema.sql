@@ -43,6 +43,34 @@ CREATE TABLE public.composite (
 
 ALTER TABLE public.composite OWNER TO stats;
 
+--
+-- Name: conference_week_results; Type: TABLE; Schema: public; Owner: stats
+--
+
+CREATE TABLE public.conference_week_results (
+    conf text NOT NULL,
+    year integer NOT NULL,
+    week integer NOT NULL,
+    postseason integer DEFAULT 0 NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    model text DEFAULT 'composite' NOT NULL,
+    division text DEFAULT 'fbs' NOT NULL,
+    fbs boolean DEFAULT false NOT NULL,
+    teams integer DEFAULT 0,
+    rank integer DEFAULT 0,
+    mean_raw real DEFAULT 0,
+    median_raw real DEFAULT 0,
+    depth real DEFAULT 0,
+    best_rank integer DEFAULT 0,
+    worst_rank integer DEFAULT 0,
+    non_conf_wins integer DEFAULT 0,
+    non_conf_losses integer DEFAULT 0,
+    non_conf_ties integer DEFAULT 0
+);
+
+
+ALTER TABLE public.conference_week_results OWNER TO stats;
+
 --
 -- Name: defensive_stats; Type: TABLE; Schema: public; Owner: stats
 --
@@ -63,6 +91,23 @@ CREATE TABLE public.defensive_stats (
 
 ALTER TABLE public.defensive_stats OWNER TO stats;
 
+--
+-- Name: elo_history; Type: TABLE; Schema: public; Owner: stats
+--
+
+CREATE TABLE public.elo_history (
+    game_id integer NOT NULL,
+    team_id integer NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    season integer DEFAULT 0,
+    start_time timestamp with time zone,
+    pre_rating double precision DEFAULT 0,
+    post_rating double precision DEFAULT 0
+);
+
+
+ALTER TABLE public.elo_history OWNER TO stats;
+
 --
 -- Name: fumble_stats; Type: TABLE; Schema: public; Owner: stats
 --
@@ -96,7 +141,9 @@ CREATE TABLE public.games (
     retry integer DEFAULT 0,
     start_time timestamp with time zone,
     home_score integer DEFAULT 0,
-    away_score integer DEFAULT 0
+    away_score integer DEFAULT 0,
+    status text DEFAULT 'final' NOT NULL,
+    venue text
 );
 
 
@@ -278,6 +325,31 @@ CREATE TABLE public.rushing_stats (
 
 ALTER TABLE public.rushing_stats OWNER TO stats;
 
+--
+-- Name: season_simulations; Type: TABLE; Schema: public; Owner: stats
+--
+
+CREATE TABLE public.season_simulations (
+    team_id integer NOT NULL,
+    year integer NOT NULL,
+    week integer NOT NULL,
+    sport text DEFAULT 'ncaaf' NOT NULL,
+    name text,
+    conf text,
+    division text DEFAULT 'fbs',
+    fbs boolean,
+    runs integer DEFAULT 0,
+    seed bigint DEFAULT 0,
+    mean_wins double precision DEFAULT 0,
+    win_dist text,
+    conf_win_prob double precision DEFAULT 0,
+    top_n integer DEFAULT 0,
+    top_n_prob double precision DEFAULT 0
+);
+
+
+ALTER TABLE public.season_simulations OWNER TO stats;
+
 --
 -- Name: team_game_stats; Type: TABLE; Schema: public; Owner: stats
 --
@@ -341,6 +413,7 @@ CREATE TABLE public.team_seasons (
     team_id integer NOT NULL,
     year integer NOT NULL,
     sport text DEFAULT 'ncaaf',
+    division text DEFAULT 'fbs',
     fbs integer DEFAULT 0,
     power_five integer DEFAULT 0,
     conf text
@@ -366,11 +439,18 @@ CREATE TABLE public.team_week_results (
     srs_rank integer DEFAULT 0,
     sos_rank integer DEFAULT 0,
     sov_rank integer DEFAULT 0,
+    division text DEFAULT 'fbs',
     fbs boolean,
     name text,
     conf text,
     sol_rank integer DEFAULT 0,
-    ties integer DEFAULT 0
+    ties integer DEFAULT 0,
+    model text DEFAULT 'composite' NOT NULL,
+    final_raw_low real DEFAULT 0,
+    final_raw_high real DEFAULT 0,
+    rank_best integer DEFAULT 0,
+    rank_worst integer DEFAULT 0,
+    overall_rank integer DEFAULT 0
 );
 
 
@@ -384,6 +464,14 @@ ALTER TABLE ONLY public.composite
     ADD CONSTRAINT composite_pkey PRIMARY KEY (team_id, year);
 
 
+--
+-- Name: conference_week_results conference_week_results_pkey; Type: CONSTRAINT; Schema: public; Owner: stats
+--
+
+ALTER TABLE ONLY public.conference_week_results
+    ADD CONSTRAINT conference_week_results_pkey PRIMARY KEY (conf, year, week, postseason, sport, model, division);
+
+
 --
 -- Name: defensive_stats defensive_stats_pkey; Type: CONSTRAINT; Schema: public; Owner: stats
 --
@@ -392,6 +480,14 @@ ALTER TABLE ONLY public.defensive_stats
     ADD CONSTRAINT defensive_stats_pkey PRIMARY KEY (player_id, team_id, game_id);
 
 
+--
+-- Name: elo_history elo_history_pkey; Type: CONSTRAINT; Schema: public; Owner: stats
+--
+
+ALTER TABLE ONLY public.elo_history
+    ADD CONSTRAINT elo_history_pkey PRIMARY KEY (game_id, team_id, sport);
+
+
 --
 -- Name: fumble_stats fumble_stats_pkey; Type: CONSTRAINT; Schema: public; Owner: stats
 --
@@ -488,6 +584,14 @@ ALTER TABLE ONLY public.rushing_stats
     ADD CONSTRAINT rushing_stats_pkey PRIMARY KEY (player_id, team_id, game_id);
 
 
+--
+-- Name: season_simulations season_simulations_pkey; Type: CONSTRAINT; Schema: public; Owner: stats
+--
+
+ALTER TABLE ONLY public.season_simulations
+    ADD CONSTRAINT season_simulations_pkey PRIMARY KEY (team_id, year, week, sport);
+
+
 --
 -- Name: team_game_stats team_game_stats_pkey; Type: CONSTRAINT; Schema: public; Owner: stats
 --
@@ -517,7 +621,21 @@ ALTER TABLE ONLY public.team_seasons
 --
 
 ALTER TABLE ONLY public.team_week_results
-    ADD CONSTRAINT team_week_result_pkey PRIMARY KEY (team_id, year, week, postseason, sport);
+    ADD CONSTRAINT team_week_result_pkey PRIMARY KEY (team_id, year, week, postseason, sport, model);
+
+
+--
+-- Name: division_index; Type: INDEX; Schema: public; Owner: stats
+--
+
+CREATE INDEX division_index ON public.team_week_results USING btree (division);
+
+
+--
+-- Name: elo_history_start_time_index; Type: INDEX; Schema: public; Owner: stats
+--
+
+CREATE INDEX elo_history_start_time_index ON public.elo_history USING btree (start_time);
 
 
 --
@@ -562,6 +680,13 @@ CREATE INDEX game_season_index ON public.games USING btree (season);
 CREATE INDEX game_start_time_index ON public.games USING btree (start_time);
 
 
+--
+-- Name: game_status_index; Type: INDEX; Schema: public; Owner: stats
+--
+
+CREATE INDEX game_status_index ON public.games USING btree (status);
+
+
 --
 -- Name: game_week_index; Type: INDEX; Schema: public; Owner: stats
 --
@@ -612,6 +737,14 @@ ALTER TABLE ONLY public.defensive_stats
     ADD CONSTRAINT defensive_stats_game_id_fkey FOREIGN KEY (game_id) REFERENCES public.games(game_id) ON DELETE CASCADE;
 
 
+--
+-- Name: elo_history elo_history_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: stats
+--
+
+ALTER TABLE ONLY public.elo_history
+    ADD CONSTRAINT elo_history_game_id_fkey FOREIGN KEY (game_id) REFERENCES public.games(game_id) ON DELETE CASCADE;
+
+
 --
 -- Name: fumble_stats fumble_stats_game_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: stats
 --
@@ -0,0 +1,233 @@
+// Package backtest scores rating-based predictions against results that were
+// not used to make them.
+package backtest
+
+import (
+	"math"
+
+	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/predict"
+	"github.com/robby-barton/stats-go/internal/ranking"
+)
+
+// logLossEpsilon keeps a confident miss from making the log loss infinite.
+const logLossEpsilon = 1e-15
+
+// Score accumulates prediction metrics over a set of games.
+type Score struct {
+	Games   int
+	correct float64
+	absErr  float64
+	brier   float64
+	logLoss float64
+
+	// ModelGames and modelCorrect track how often the better-ranked team won
+	// according to the ranking model under test.
+	ModelGames   int
+	modelCorrect float64
+}
+
+// Add scores a prediction against the final score. A tie counts as half a
+// correct pick.
+func (s *Score) Add(p predict.Prediction, homeScore int64, awayScore int64) {
+	outcome := 0.5
+	switch {
+	case homeScore > awayScore:
+		outcome = 1
+	case homeScore < awayScore:
+		outcome = 0
+	}
+
+	s.Games++
+	s.correct += pickCredit(p.Margin, outcome)
+	s.absErr += math.Abs(float64(homeScore-awayScore) - p.Margin)
+	s.brier += (p.HomeWinProb - outcome) * (p.HomeWinProb - outcome)
+
+	prob := min(max(p.HomeWinProb, logLossEpsilon), 1-logLossEpsilon)
+	s.logLoss -= outcome*math.Log(prob) + (1-outcome)*math.Log(1-prob)
+}
+
+// AddModelPick scores the pick of a ranking model, which favors the team with
+// the better (lower) final rank.
+func (s *Score) AddModelPick(homeRank int64, awayRank int64, homeScore int64, awayScore int64) {
+	outcome := 0.5
+	switch {
+	case homeScore > awayScore:
+		outcome = 1
+	case homeScore < awayScore:
+		outcome = 0
+	}
+
+	s.ModelGames++
+	s.modelCorrect += pickCredit(float64(awayRank-homeRank), outcome)
+}
+
+// Merge adds other's games to s.
+func (s *Score) Merge(other Score) {
+	s.Games += other.Games
+	s.correct += other.correct
+	s.absErr += other.absErr
+	s.brier += other.brier
+	s.logLoss += other.logLoss
+	s.ModelGames += other.ModelGames
+	s.modelCorrect += other.modelCorrect
+}
+
+// Accuracy is the share of games whose winner was picked correctly.
+func (s Score) Accuracy() float64 {
+	return ratio(s.correct, s.Games)
+}
+
+// MeanAbsError is the mean absolute difference between predicted and actual
+// home margins.
+func (s Score) MeanAbsError() float64 {
+	return ratio(s.absErr, s.Games)
+}
+
+// Brier is the mean squared error of the home win probability.
+func (s Score) Brier() float64 {
+	return ratio(s.brier, s.Games)
+}
+
+// LogLoss is the mean negative log likelihood of the results.
+func (s Score) LogLoss() float64 {
+	return ratio(s.logLoss, s.Games)
+}
+
+// ModelAccuracy is the share of games won by the team the ranking model
+// ranked higher.
+func (s Score) ModelAccuracy() float64 {
+	return ratio(s.modelCorrect, s.ModelGames)
+}
+
+// pickCredit scores picking the home team when favor is positive and the away
+// team when it is negative. No pick, or a tie, earns half credit.
+func pickCredit(favor float64, outcome float64) float64 {
+	switch {
+	case outcome == 0.5 || favor == 0:
+		return 0.5
+	case (favor > 0) == (outcome == 1):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func ratio(total float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// Backtest replays seasons week by week, rating teams with only the games
+// before each week and scoring predictions of that week's games.
+type Backtest struct {
+	DB        *gorm.DB
+	Sport     string
+	Division  database.Division // football division; empty selects FBS
+	StartWeek int64             // first week of each season to predict
+	Model     string            // ranking model whose picks are also scored; empty skips it
+}
+
+// SeasonScore is the score for one season.
+type SeasonScore struct {
+	Year int64
+	Score
+}
+
+// Run backtests every season from from to to, inclusive. Each season's games
+// are loaded with the YearsBack seasons before it that its rankings reach,
+// so memory holds a few seasons however many are backtested.
+func (b *Backtest) Run(from int64, to int64) ([]SeasonScore, error) {
+	var scores []SeasonScore
+	for year := from; year <= to; year++ {
+		source, err := ranking.LoadGameSource(b.DB, b.Sport, year-ranking.YearsBack(b.Sport), year)
+		if err != nil {
+			return nil, err
+		}
+		score, err := b.season(year, source)
+		if err != nil {
+			return nil, err
+		}
+		if score.Games > 0 {
+			scores = append(scores, SeasonScore{Year: year, Score: score})
+		}
+	}
+
+	return scores, nil
+}
+
+func (b *Backtest) season(year int64, source ranking.GameSource) (Score, error) {
+	var weeks []int64
+	if err := b.DB.Model(database.Game{}).
+		Where("sport = ? and status = ? and season = ? and postseason = 0 and week >= ?",
+			b.Sport, database.GameFinal, year, b.StartWeek).
+		Distinct("week").
+		Order("week").
+		Pluck("week", &weeks).Error; err != nil {
+		return Score{}, err
+	}
+
+	var score Score
+	for _, week := range weeks {
+		weekScore, err := b.week(year, week, source)
+		if err != nil {
+			return Score{}, err
+		}
+		score.Merge(weekScore)
+	}
+
+	return score, nil
+}
+
+func (b *Backtest) week(year int64, week int64, source ranking.GameSource) (Score, error) {
+	predictor, err := predict.NewPredictor(&ranking.Ranker{
+		DB: b.DB, Year: year, Week: week, Division: b.Division, Sport: b.Sport, Games: source,
+	})
+	if err != nil {
+		return Score{}, err
+	}
+	if len(predictor.Ratings.Ratings) == 0 {
+		return Score{}, nil
+	}
+
+	var teamList ranking.TeamList
+	if b.Model != "" {
+		r := ranking.Ranker{
+			DB: b.DB, Year: year, Week: week, Division: b.Division, Sport: b.Sport, Model: b.Model, Games: source,
+		}
+		if teamList, err = r.CalculateRanking(); err != nil {
+			return Score{}, err
+		}
+	}
+
+	var games []database.Game
+	if err := b.DB.
+		Where("sport = ? and status = ? and season = ? and week = ? and postseason = 0",
+			b.Sport, database.GameFinal, year, week).
+		Find(&games).Error; err != nil {
+		return Score{}, err
+	}
+
+	var score Score
+	for _, game := range games {
+		prediction, err := predictor.Game(game.HomeID, game.AwayID, game.Neutral)
+		if err != nil {
+			// a team outside the rated division
+			continue
+		}
+		score.Add(prediction, game.HomeScore, game.AwayScore)
+
+		if teamList != nil {
+			home, away := teamList[game.HomeID], teamList[game.AwayID]
+			if home != nil && away != nil {
+				score.AddModelPick(home.FinalRank, away.FinalRank, game.HomeScore, game.AwayScore)
+			}
+		}
+	}
+
+	return score, nil
+}
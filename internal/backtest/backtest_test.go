@@ -0,0 +1,131 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/predict"
+)
+
+func TestScore(t *testing.T) {
+	var score Score
+	// Right pick, 3 points off.
+	score.Add(predict.Prediction{Margin: 7, HomeWinProb: 0.75}, 24, 14)
+	// Wrong pick.
+	score.Add(predict.Prediction{Margin: -3, HomeWinProb: 0.4}, 21, 20)
+	// Tie: half credit.
+	score.Add(predict.Prediction{Margin: 1, HomeWinProb: 0.5}, 17, 17)
+
+	if score.Games != 3 {
+		t.Fatalf("Games = %d, want 3", score.Games)
+	}
+	if got := score.Accuracy(); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("Accuracy = %f, want 0.5", got)
+	}
+	if got := score.MeanAbsError(); math.Abs(got-(3.0+4+1)/3) > 1e-9 {
+		t.Errorf("MeanAbsError = %f, want %f", got, (3.0+4+1)/3)
+	}
+	wantBrier := (0.25*0.25 + 0.6*0.6 + 0) / 3
+	if got := score.Brier(); math.Abs(got-wantBrier) > 1e-9 {
+		t.Errorf("Brier = %f, want %f", got, wantBrier)
+	}
+	wantLogLoss := -(math.Log(0.75) + math.Log(0.4) + math.Log(0.5)) / 3
+	if got := score.LogLoss(); math.Abs(got-wantLogLoss) > 1e-9 {
+		t.Errorf("LogLoss = %f, want %f", got, wantLogLoss)
+	}
+
+	// A certain miss is penalized, not infinite.
+	var confident Score
+	confident.Add(predict.Prediction{Margin: 30, HomeWinProb: 1}, 0, 7)
+	if got := confident.LogLoss(); math.IsInf(got, 0) || got < 30 {
+		t.Errorf("LogLoss of a certain miss = %f, want large and finite", got)
+	}
+}
+
+func TestScore_ModelPickAndMerge(t *testing.T) {
+	var a, b Score
+	a.AddModelPick(1, 5, 28, 10) // higher-ranked home team wins
+	b.AddModelPick(2, 9, 10, 13) // higher-ranked home team loses
+
+	a.Merge(b)
+	if a.ModelGames != 2 {
+		t.Fatalf("ModelGames = %d, want 2", a.ModelGames)
+	}
+	if got := a.ModelAccuracy(); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("ModelAccuracy = %f, want 0.5", got)
+	}
+	if a.Games != 0 || a.Accuracy() != 0 {
+		t.Errorf("Games/Accuracy = %d/%f, want 0/0", a.Games, a.Accuracy())
+	}
+}
+
+func TestRun(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&database.Game{}, &database.TeamSeason{}, &database.TeamName{}, &database.EloHistory{},
+	); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	var names []database.TeamName
+	var seasons []database.TeamSeason
+	for id := int64(1); id <= 4; id++ {
+		names = append(names, database.TeamName{TeamID: id, Name: string(rune('A' + id - 1)), Sport: "ncaaf"})
+		seasons = append(seasons, database.TeamSeason{TeamID: id, Year: 2023, Division: database.DivisionFBS, Sport: "ncaaf"})
+	}
+	if err := db.Create(&names).Error; err != nil {
+		t.Fatalf("seed team_names: %v", err)
+	}
+	if err := db.Create(&seasons).Error; err != nil {
+		t.Fatalf("seed team_seasons: %v", err)
+	}
+
+	// Team 1 beats everyone, team 4 loses to everyone, every week.
+	base := time.Date(2023, 9, 2, 19, 0, 0, 0, time.UTC)
+	matchups := [][2]int64{{1, 2}, {3, 4}, {1, 3}, {2, 4}, {1, 4}, {2, 3}}
+	var games []database.Game
+	for i, m := range matchups {
+		week := int64(i/2 + 1)
+		games = append(games, database.Game{
+			GameID: int64(i + 1), Season: 2023, Week: week, HomeID: m[0], AwayID: m[1],
+			HomeScore: 28, AwayScore: 14 - int64(i), Sport: "ncaaf",
+			StartTime: base.Add(time.Duration(week-1) * 7 * 24 * time.Hour).Add(time.Duration(i) * time.Hour),
+		})
+	}
+	// An unplayed game is never scored.
+	games = append(games, database.Game{
+		GameID: 99, Season: 2023, Week: 3, HomeID: 4, AwayID: 1, Sport: "ncaaf", Status: database.GameScheduled,
+		StartTime: base.Add(14 * 24 * time.Hour),
+	})
+	if err := db.Create(&games).Error; err != nil {
+		t.Fatalf("seed games: %v", err)
+	}
+
+	b := Backtest{DB: db, Sport: "ncaaf", StartWeek: 2, Model: "composite"}
+	scores, err := b.Run(2022, 2023)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	// 2022 has no games; 2023 scores weeks 2 and 3.
+	if len(scores) != 1 || scores[0].Year != 2023 {
+		t.Fatalf("scores = %+v, want one 2023 season", scores)
+	}
+	if scores[0].Games != 4 || scores[0].ModelGames != 4 {
+		t.Errorf("Games/ModelGames = %d/%d, want 4/4", scores[0].Games, scores[0].ModelGames)
+	}
+	if scores[0].Accuracy() < 0.5 {
+		t.Errorf("Accuracy = %f, want >= 0.5 when favorites always win", scores[0].Accuracy())
+	}
+}
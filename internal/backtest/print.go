@@ -0,0 +1,47 @@
+//nolint:forbidigo // backtest doesn't have a logger
+package backtest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// PrintScores writes one row per season followed by the aggregate over all
+// seasons.
+func (b *Backtest) PrintScores(scores []SeasonScore) {
+	fmt.Printf("Predicting from week %d of each season\n", b.StartWeek)
+
+	header := table.Row{"Season", "Games", "Accuracy", "MAE", "Brier", "Log loss"}
+	if b.Model != "" {
+		header = append(header, b.Model+" accuracy")
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(header)
+
+	var total Score
+	for _, season := range scores {
+		t.AppendRow(b.scoreRow(season.Year, season.Score))
+		total.Merge(season.Score)
+	}
+	t.AppendFooter(b.scoreRow("All", total))
+	t.Render()
+}
+
+func (b *Backtest) scoreRow(label any, score Score) table.Row {
+	row := table.Row{
+		label, score.Games,
+		fmt.Sprintf("%.3f", score.Accuracy()),
+		fmt.Sprintf("%.2f", score.MeanAbsError()),
+		fmt.Sprintf("%.4f", score.Brier()),
+		fmt.Sprintf("%.4f", score.LogLoss()),
+	}
+	if b.Model != "" {
+		row = append(row, fmt.Sprintf("%.3f", score.ModelAccuracy()))
+	}
+	return row
+}
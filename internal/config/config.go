@@ -13,6 +13,8 @@ type Config struct {
 	Env          string
 	DBParams     *database.DBParams
 	DeployScript string
+	ArchiveDir   string // ESPN response archive; empty disables archiving
+	CacheDir     string // ESPN response cache backing; empty keeps it in memory
 }
 
 func SetupConfig() *Config {
@@ -36,5 +38,7 @@ func SetupConfig() *Config {
 			SSLMode:  os.Getenv("PG_SSLMODE"),
 		},
 		DeployScript: os.Getenv("DEPLOY_SCRIPT"),
+		ArchiveDir:   os.Getenv("ESPN_ARCHIVE_DIR"),
+		CacheDir:     os.Getenv("ESPN_CACHE_DIR"),
 	}
 }
@@ -39,12 +39,36 @@ func (TeamName) TableName() string {
 	return "team_names"
 }
 
+// Division is a team's division in a season. Basketball ranks Division I as
+// one group, stored as DivisionFBS, the top division.
+type Division string
+
+const (
+	DivisionFBS Division = "fbs"
+	DivisionFCS Division = "fcs"
+	DivisionII  Division = "dii"
+	DivisionIII Division = "diii"
+)
+
+// IsFBS reports whether d is the top division. It fills the legacy fbs
+// columns, which are written alongside division until stats-web reads
+// division (see docs/tech-debt.md).
+func (d Division) IsFBS() bool {
+	return d == DivisionFBS
+}
+
+// FootballDivisions returns the football divisions, top first.
+func FootballDivisions() []Division {
+	return []Division{DivisionFBS, DivisionFCS, DivisionII, DivisionIII}
+}
+
 type TeamSeason struct {
-	TeamID int64  `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
-	Year   int64  `json:"year" gorm:"column:year;primaryKey"`
-	Sport  string `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
-	FBS    int64  `json:"fbs" gorm:"column:fbs"`
-	Conf   string `json:"conf" gorm:"column:conf"`
+	TeamID   int64    `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
+	Year     int64    `json:"year" gorm:"column:year;primaryKey"`
+	Sport    string   `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
+	Division Division `json:"division" gorm:"column:division;default:fbs"`
+	FBS      int64    `json:"fbs" gorm:"column:fbs"` // legacy, 1 when Division is fbs
+	Conf     string   `json:"conf" gorm:"column:conf"`
 }
 
 func (TeamSeason) TableName() string {
@@ -52,33 +76,80 @@ func (TeamSeason) TableName() string {
 }
 
 type TeamWeekResult struct {
-	TeamID     int64   `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
-	Name       string  `json:"name" gorm:"column:name;not null"`
-	Conf       string  `json:"conf" gorm:"column:conf"`
-	Year       int64   `json:"year" gorm:"column:year;primaryKey;not null"`
-	Week       int64   `json:"week" gorm:"column:week;primaryKey;not null"`
-	Postseason int64   `json:"postseason" gorm:"column:postseason;primaryKey"`
-	Sport      string  `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
-	FinalRank  int64   `json:"final_rank" gorm:"column:final_rank"`
-	FinalRaw   float64 `json:"final_raw" gorm:"column:final_raw"`
-	Wins       int64   `json:"wins" gorm:"column:wins"`
-	Losses     int64   `json:"losses" gorm:"column:losses"`
-	Ties       int64   `json:"ties" gorm:"column:ties"`
-	SRSRank    int64   `json:"srs_rank" gorm:"column:srs_rank"`
-	SOSRank    int64   `json:"sos_rank" gorm:"column:sos_rank"`
-	SOVRank    int64   `json:"sov_rank" gorm:"column:sov_rank"`
-	SOLRank    int64   `json:"sol_rank" gorm:"column:sol_rank"`
-	Fbs        bool    `json:"fbs" gorm:"column:fbs"`
+	TeamID     int64    `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
+	Name       string   `json:"name" gorm:"column:name;not null"`
+	Conf       string   `json:"conf" gorm:"column:conf"`
+	Year       int64    `json:"year" gorm:"column:year;primaryKey;not null"`
+	Week       int64    `json:"week" gorm:"column:week;primaryKey;not null"`
+	Postseason int64    `json:"postseason" gorm:"column:postseason;primaryKey"`
+	Sport      string   `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
+	Model      string   `json:"model" gorm:"column:model;primaryKey;default:composite"`
+	FinalRank  int64    `json:"final_rank" gorm:"column:final_rank"`
+	FinalRaw   float64  `json:"final_raw" gorm:"column:final_raw"`
+	Wins       int64    `json:"wins" gorm:"column:wins"`
+	Losses     int64    `json:"losses" gorm:"column:losses"`
+	Ties       int64    `json:"ties" gorm:"column:ties"`
+	SRSRank    int64    `json:"srs_rank" gorm:"column:srs_rank"`
+	SOSRank    int64    `json:"sos_rank" gorm:"column:sos_rank"`
+	SOVRank    int64    `json:"sov_rank" gorm:"column:sov_rank"`
+	SOLRank    int64    `json:"sol_rank" gorm:"column:sol_rank"`
+	Division   Division `json:"division" gorm:"column:division;default:fbs"`
+	Fbs        bool     `json:"fbs" gorm:"column:fbs"` // legacy, Division.IsFBS()
+	// Bootstrap ranges, zero when the ranking was stored without them.
+	FinalRawLow  float64 `json:"final_raw_low" gorm:"column:final_raw_low"`
+	FinalRawHigh float64 `json:"final_raw_high" gorm:"column:final_raw_high"`
+	RankBest     int64   `json:"rank_best" gorm:"column:rank_best"`
+	RankWorst    int64   `json:"rank_worst" gorm:"column:rank_worst"`
+	// OverallRank is the rank across FBS and FCS in a unified ranking, where
+	// FinalRank is the rank within the team's division; zero otherwise.
+	OverallRank int64 `json:"overall_rank" gorm:"column:overall_rank"`
 }
 
 func (TeamWeekResult) TableName() string {
 	return "team_week_results"
 }
 
+// ConferenceWeekResult is one conference's strength in a stored ranking.
+type ConferenceWeekResult struct {
+	Conf          string   `json:"conf" gorm:"column:conf;primaryKey;not null"`
+	Year          int64    `json:"year" gorm:"column:year;primaryKey;not null"`
+	Week          int64    `json:"week" gorm:"column:week;primaryKey;not null"`
+	Postseason    int64    `json:"postseason" gorm:"column:postseason;primaryKey"`
+	Sport         string   `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
+	Model         string   `json:"model" gorm:"column:model;primaryKey;default:composite"`
+	Division      Division `json:"division" gorm:"column:division;primaryKey;default:fbs"`
+	Fbs           bool     `json:"fbs" gorm:"column:fbs"` // legacy, Division.IsFBS()
+	Teams         int64    `json:"teams" gorm:"column:teams"`
+	Rank          int64    `json:"rank" gorm:"column:rank"`
+	MeanRaw       float64  `json:"mean_raw" gorm:"column:mean_raw"`
+	MedianRaw     float64  `json:"median_raw" gorm:"column:median_raw"`
+	Depth         float64  `json:"depth" gorm:"column:depth"`
+	BestRank      int64    `json:"best_rank" gorm:"column:best_rank"`
+	WorstRank     int64    `json:"worst_rank" gorm:"column:worst_rank"`
+	NonConfWins   int64    `json:"non_conf_wins" gorm:"column:non_conf_wins"`
+	NonConfLosses int64    `json:"non_conf_losses" gorm:"column:non_conf_losses"`
+	NonConfTies   int64    `json:"non_conf_ties" gorm:"column:non_conf_ties"`
+}
+
+func (ConferenceWeekResult) TableName() string {
+	return "conference_week_results"
+}
+
+// Game statuses. Only final games count toward rankings.
+const (
+	GameScheduled  = "scheduled"
+	GameInProgress = "in_progress"
+	GameFinal      = "final"
+	GamePostponed  = "postponed"
+	GameCanceled   = "canceled"
+)
+
 type Game struct {
 	GameID     int64     `json:"game_id" gorm:"column:game_id;primaryKey;not null;unique"`
 	StartTime  time.Time `json:"start_time" gorm:"column:start_time"`
 	Sport      string    `json:"sport" gorm:"column:sport;default:ncaaf"`
+	Status     string    `json:"status" gorm:"column:status;default:final;index:game_status_index"`
+	Venue      string    `json:"venue" gorm:"column:venue"`
 	Neutral    bool      `json:"neutral" gorm:"column:neutral"`
 	ConfGame   bool      `json:"conf_game" gorm:"column:conf_game"`
 	Season     int64     `json:"season" gorm:"column:season"`
@@ -95,6 +166,42 @@ func (Game) TableName() string {
 	return "games"
 }
 
+type EloHistory struct {
+	GameID     int64     `json:"game_id" gorm:"column:game_id;primaryKey;not null"`
+	TeamID     int64     `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
+	Sport      string    `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
+	Season     int64     `json:"season" gorm:"column:season"`
+	StartTime  time.Time `json:"start_time" gorm:"column:start_time;index:elo_history_start_time_index"`
+	PreRating  float64   `json:"pre_rating" gorm:"column:pre_rating"`
+	PostRating float64   `json:"post_rating" gorm:"column:post_rating"`
+}
+
+func (EloHistory) TableName() string {
+	return "elo_history"
+}
+
+type SeasonSimulation struct {
+	TeamID      int64     `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
+	Year        int64     `json:"year" gorm:"column:year;primaryKey;not null"`
+	Week        int64     `json:"week" gorm:"column:week;primaryKey;not null"`
+	Sport       string    `json:"sport" gorm:"column:sport;primaryKey;default:ncaaf"`
+	Name        string    `json:"name" gorm:"column:name"`
+	Conf        string    `json:"conf" gorm:"column:conf"`
+	Division    Division  `json:"division" gorm:"column:division;default:fbs"`
+	Fbs         bool      `json:"fbs" gorm:"column:fbs"` // legacy, Division.IsFBS()
+	Runs        int64     `json:"runs" gorm:"column:runs"`
+	Seed        int64     `json:"seed" gorm:"column:seed"`
+	MeanWins    float64   `json:"mean_wins" gorm:"column:mean_wins"`
+	WinDist     []float64 `json:"win_dist" gorm:"column:win_dist;serializer:json"`
+	ConfWinProb float64   `json:"conf_win_prob" gorm:"column:conf_win_prob"`
+	TopN        int64     `json:"top_n" gorm:"column:top_n"`
+	TopNProb    float64   `json:"top_n_prob" gorm:"column:top_n_prob"`
+}
+
+func (SeasonSimulation) TableName() string {
+	return "season_simulations"
+}
+
 type TeamGameStats struct {
 	GameID             int64 `json:"game_id" gorm:"column:game_id;primaryKey;not null"`
 	TeamID             int64 `json:"team_id" gorm:"column:team_id;primaryKey;not null"`
@@ -0,0 +1,139 @@
+package espn
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Endpoints whose responses are archived.
+const (
+	endpointSchedule   = "schedule"
+	endpointGame       = "game"
+	endpointTeams      = "teams"
+	endpointScoreboard = "scoreboard"
+)
+
+// archiveKey names an archived response: the endpoint it came from and the
+// game ID or request parameters that identify it.
+type archiveKey struct {
+	endpoint string
+	id       string
+	// live marks a schedule that still changes as games finish: the
+	// current schedule, or one for a recent or upcoming date.
+	live bool
+}
+
+func gameKey(gameID int64) archiveKey {
+	return archiveKey{endpoint: endpointGame, id: strconv.FormatInt(gameID, 10)}
+}
+
+// latestKey keys a response from an endpoint that takes no parameters, so
+// only its most recent response is kept.
+func latestKey(endpoint string) archiveKey {
+	return archiveKey{endpoint: endpoint, id: "latest"}
+}
+
+// scheduleKey keys a schedule response by the parameters appended to the
+// schedule URL, e.g. "&year=2024&week=3" becomes "year-2024_week-3". The
+// current schedule, with no parameters, is "latest".
+func scheduleKey(params string) archiveKey {
+	id := strings.NewReplacer("&", "_", "=", "-").Replace(strings.TrimPrefix(params, "&"))
+	if id == "" {
+		key := latestKey(endpointSchedule)
+		key.live = true
+		return key
+	}
+
+	query, _ := url.ParseQuery(strings.TrimPrefix(params, "&"))
+	live := !query.Has("year") && !query.Has("date")
+	if date, err := time.Parse("20060102", query.Get("date")); err == nil {
+		live = date.After(time.Now().AddDate(0, 0, -2))
+	}
+	return archiveKey{endpoint: endpointSchedule, id: id, live: live}
+}
+
+// archivePath returns where the response for key is archived under dir.
+func archivePath(dir string, sport Sport, key archiveKey) string {
+	return filepath.Join(dir, sport.SportDB(), key.endpoint, key.id+".json.gz")
+}
+
+// archive writes a raw response body, gzip-compressed, to the client's
+// archive directory, replacing any earlier response for the same key.
+func (c *Client) archive(key archiveKey, body []byte) error {
+	path := archivePath(c.ArchiveDir, c.Sport, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// archiveBody archives a valid response body from endpoint when the client
+// has an ArchiveDir. The archive is a copy on the side, so a failed write is
+// logged rather than failing the request.
+func (c *Client) archiveBody(endpoint string, key archiveKey, body []byte) {
+	if c.ArchiveDir == "" {
+		return
+	}
+	if err := c.archive(key, body); err != nil {
+		c.logger().Warnf("archiving response from %q: %v", endpoint, err)
+	}
+}
+
+// ArchivedGameStats reads a game's stats response from an archive directory
+// written by a Client with ArchiveDir set, without network access. A game
+// that was never archived returns an error matching fs.ErrNotExist.
+func ArchivedGameStats(dir string, sport Sport, gameID int64) (*GameInfoESPN, error) {
+	path := archivePath(dir, sport, gameKey(gameID))
+	body, err := readArchive(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var res GameInfoESPN
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("decoding archived response %q: %w", path, err)
+	}
+	if err := res.validate(); err != nil {
+		return nil, fmt.Errorf("archived response %q: %w", path, err)
+	}
+
+	return &res, nil
+}
+
+func readArchive(path string) ([]byte, error) {
+	compressed, err := os.ReadFile(path) //nolint:gosec // path is built from the configured archive dir
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("reading archived response %q: %w", path, err)
+	}
+	defer zr.Close()
+
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("reading archived response %q: %w", path, err)
+	}
+	return body, nil
+}
@@ -1,6 +1,7 @@
 package espn
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"sync"
@@ -10,31 +11,34 @@ import (
 // BasketballClient wraps a shared *Client with basketball-specific season logic.
 type BasketballClient struct {
 	*Client
-	cachedSeason     int64
-	cachedSeasonErr  error
-	cachedSeasonOnce sync.Once
+	cachedSeason   int64
+	cachedSeasonMu sync.Mutex
 }
 
 // Compile-time interface check.
 var _ SportClient = (*BasketballClient)(nil)
 
-func (bc *BasketballClient) DefaultSeason() (int64, error) {
-	bc.cachedSeasonOnce.Do(func() {
-		sb, err := bc.GetScoreboard()
+func (bc *BasketballClient) DefaultSeason(ctx context.Context) (int64, error) {
+	bc.cachedSeasonMu.Lock()
+	defer bc.cachedSeasonMu.Unlock()
+
+	// Only a fetched season is cached: an error, such as a canceled context,
+	// leaves the next call to try again.
+	if bc.cachedSeason == 0 {
+		sb, err := bc.GetScoreboard(ctx)
 		if err != nil {
-			bc.cachedSeasonErr = err
-			return
+			return 0, err
 		}
 		bc.cachedSeason = sb.Leagues[0].Season.Year
-	})
-	return bc.cachedSeason, bc.cachedSeasonErr
+	}
+	return bc.cachedSeason, nil
 }
 
 // validateCurrentSeason returns an error if year does not match the current ESPN season.
 // Used only for methods that have no historical equivalent (GetWeeksInSeason,
 // HasPostseasonStarted) and are only called by the current-season scheduler.
-func (bc *BasketballClient) validateCurrentSeason(year int64) error {
-	current, err := bc.DefaultSeason()
+func (bc *BasketballClient) validateCurrentSeason(ctx context.Context, year int64) error {
+	current, err := bc.DefaultSeason(ctx)
 	if err != nil {
 		return err
 	}
@@ -44,15 +48,15 @@ func (bc *BasketballClient) validateCurrentSeason(year int64) error {
 	return nil
 }
 
-func (bc *BasketballClient) GetWeeksInSeason(year int64) (int64, error) {
-	if err := bc.validateCurrentSeason(year); err != nil {
+func (bc *BasketballClient) GetWeeksInSeason(ctx context.Context, year int64) (int64, error) {
+	if err := bc.validateCurrentSeason(ctx, year); err != nil {
 		return 0, err
 	}
-	return bc.getWeeksInSeasonFromScoreboard()
+	return bc.getWeeksInSeasonFromScoreboard(ctx)
 }
 
-func (bc *BasketballClient) getWeeksInSeasonFromScoreboard() (int64, error) {
-	sb, err := bc.GetScoreboard()
+func (bc *BasketballClient) getWeeksInSeasonFromScoreboard(ctx context.Context) (int64, error) {
+	sb, err := bc.GetScoreboard(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -72,11 +76,11 @@ func (bc *BasketballClient) getWeeksInSeasonFromScoreboard() (int64, error) {
 	return weeks, nil
 }
 
-func (bc *BasketballClient) HasPostseasonStarted(year int64, _ time.Time) (bool, error) {
-	if err := bc.validateCurrentSeason(year); err != nil {
+func (bc *BasketballClient) HasPostseasonStarted(ctx context.Context, year int64, _ time.Time) (bool, error) {
+	if err := bc.validateCurrentSeason(ctx, year); err != nil {
 		return false, err
 	}
-	sb, err := bc.GetScoreboard()
+	sb, err := bc.GetScoreboard(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -98,74 +102,73 @@ func (bc *BasketballClient) historicalSeasonDates(year int64) []string {
 // getSeasonDates returns game dates for the given year.
 // For the current season it uses the scoreboard calendar (exact game dates only).
 // For historical seasons it generates the full date range for the season window.
-func (bc *BasketballClient) getSeasonDates(year int64) ([]string, error) {
-	current, err := bc.DefaultSeason()
+func (bc *BasketballClient) getSeasonDates(ctx context.Context, year int64) ([]string, error) {
+	current, err := bc.DefaultSeason(ctx)
 	if err != nil {
 		return nil, err
 	}
 	if year == current {
-		return bc.GetSeasonDates()
+		return bc.GetSeasonDates(ctx)
 	}
 	return bc.historicalSeasonDates(year), nil
 }
 
-// GetCurrentWeekGames fetches completed games from today and yesterday.
+// GetCurrentWeekGames fetches games from today and yesterday, whatever their
+// status.
 // The base Client method only returns ESPN's "current" schedule page, which
 // for basketball is a single day. If a late-night game finishes after ESPN
 // rolls to the next day, the base method would miss it permanently. Fetching
 // two days ensures the 5-minute cron has a full day of retries to catch it.
-func (bc *BasketballClient) GetCurrentWeekGames(group Group) ([]Game, error) {
+func (bc *BasketballClient) GetCurrentWeekGames(ctx context.Context, group Group) ([]Game, error) {
 	now := time.Now()
 	var allGames []Game
 	seen := make(map[int64]bool)
 
 	for daysBack := 0; daysBack <= 1; daysBack++ {
 		date := now.AddDate(0, 0, -daysBack).Format("20060102")
-		games, err := bc.GetCompletedGamesByDate(date, group)
+		res, err := bc.GetGamesByDate(ctx, date, group)
 		if err != nil {
 			return nil, err
 		}
-		for _, g := range games {
+		for _, g := range scheduleGames(res) {
 			if !seen[g.ID] {
 				seen[g.ID] = true
 				allGames = append(allGames, g)
 			}
 		}
-		if daysBack < 1 {
-			time.Sleep(bc.RateLimit)
-		}
 	}
 
 	return allGames, nil
 }
 
-func (bc *BasketballClient) GetGamesBySeason(year int64, group Group) ([]Game, error) {
-	dates, err := bc.getSeasonDates(year)
+// GetGamesBySeason returns every game of a season, including ones not yet
+// played.
+func (bc *BasketballClient) GetGamesBySeason(ctx context.Context, year int64, group Group) ([]Game, error) {
+	dates, err := bc.getSeasonDates(ctx, year)
 	if err != nil {
 		return nil, err
 	}
-	return bc.getGamesByDates(dates, group)
+	return bc.getGamesByDates(ctx, dates, group)
 }
 
-func (bc *BasketballClient) getGamesByDates(dates []string, group Group) ([]Game, error) {
+func (bc *BasketballClient) getGamesByDates(ctx context.Context, dates []string, group Group) ([]Game, error) {
 	var allGames []Game
 	for _, dateStr := range dates {
 		date := dateToParam(dateStr)
 		if date == "" {
 			continue
 		}
-		games, err := bc.GetCompletedGamesByDate(date, group)
+		res, err := bc.GetGamesByDate(ctx, date, group)
 		if err != nil {
 			return nil, err
 		}
-		allGames = append(allGames, games...)
-		time.Sleep(bc.RateLimit)
+		allGames = append(allGames, scheduleGames(res)...)
 	}
 	return allGames, nil
 }
 
-func (bc *BasketballClient) TeamConferencesByYear(year int64) (map[int64]int64, error) {
-	dates, err := bc.getSeasonDates(year)
+func (bc *BasketballClient) TeamConferencesByYear(ctx context.Context, year int64) (map[int64]int64, error) {
+	dates, err := bc.getSeasonDates(ctx, year)
 	if err != nil {
 		return nil, err
 	}
@@ -177,32 +180,31 @@ func (bc *BasketballClient) TeamConferencesByYear(year int64) (map[int64]int64,
 			if date == "" {
 				continue
 			}
-			games, err := bc.GetGamesByDate(date, group)
+			games, err := bc.GetGamesByDate(ctx, date, group)
 			if err != nil {
 				return nil, err
 			}
 			maps.Copy(teamConfs, extractTeamConfs(games))
-			time.Sleep(bc.RateLimit)
 		}
 	}
 
 	return teamConfs, nil
 }
 
-func (bc *BasketballClient) ConferenceMap() (ConferenceMapResult, error) {
+func (bc *BasketballClient) ConferenceMap(ctx context.Context) (ConferenceMapResult, error) {
 	// Use a mid-season date to guarantee regular-season conference data.
 	// During March Madness the default schedule page returns only tournament
 	// groupings (NCAA Tournament, NIT, etc.) whose parentGroupId is nil,
 	// causing the D1 conference list to come back empty.
-	current, err := bc.DefaultSeason()
+	current, err := bc.DefaultSeason(ctx)
 	if err != nil {
 		return ConferenceMapResult{}, err
 	}
 	midSeasonDate := fmt.Sprintf("%d1215", current-1) // Dec 15 of prior calendar year
 
 	var res GameScheduleESPN
-	url := bc.WeekURL() + fmt.Sprintf("&date=%s", midSeasonDate)
-	if err := bc.makeRequest(url, &res); err != nil {
+	params := "&date=" + midSeasonDate
+	if err := bc.makeRequest(ctx, bc.WeekURL()+params, scheduleKey(params), &res); err != nil {
 		return ConferenceMapResult{}, err
 	}
 
@@ -0,0 +1,179 @@
+package espn
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Default circuit breaker settings: stop calling a host after five failed
+// requests in a row and probe it again after a minute.
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCoolDown  = time.Minute
+)
+
+// ErrCircuitOpen is returned for requests short-circuited by an open Breaker.
+var ErrCircuitOpen = errors.New("circuit open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// hostCircuit is the breaker state of one host.
+type hostCircuit struct {
+	state    circuitState
+	failures int       // consecutive failed requests
+	openedAt time.Time // when the circuit last opened
+	probing  bool      // a half-open probe is in flight
+}
+
+// Breaker is a per-host circuit breaker for ESPN requests. A host's circuit
+// opens after Threshold consecutive failures (transport errors, 5xx and 429
+// responses), and requests to it fail with ErrCircuitOpen until CoolDown has
+// passed. Then a single probe request is let through: success closes the
+// circuit, failure opens it for another CoolDown. State changes are logged.
+//
+// A Breaker is safe for concurrent use and meant to be shared by every Client
+// in a process. A nil Breaker lets every request through.
+type Breaker struct {
+	Threshold int
+	CoolDown  time.Duration
+	Logger    *zap.SugaredLogger // nil discards state changes
+
+	mu    sync.Mutex
+	hosts map[string]*hostCircuit
+}
+
+// NewBreaker returns a Breaker that logs state changes to log.
+func NewBreaker(log *zap.SugaredLogger, threshold int, coolDown time.Duration) *Breaker {
+	return &Breaker{
+		Threshold: threshold,
+		CoolDown:  coolDown,
+		Logger:    log,
+		hosts:     map[string]*hostCircuit{},
+	}
+}
+
+// allow reports whether a request to host may be sent, returning
+// ErrCircuitOpen if not. A request allowed through a half-open circuit is its
+// probe and must be followed by record or release.
+func (b *Breaker) allow(host string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hc := b.circuit(host)
+	switch hc.state {
+	case circuitClosed:
+		return nil
+	case circuitOpen:
+		if time.Since(hc.openedAt) < b.CoolDown {
+			return ErrCircuitOpen
+		}
+		hc.state = circuitHalfOpen
+		hc.probing = true
+		b.logger().Infof("ESPN circuit for %s half-open, probing", host)
+		return nil
+	case circuitHalfOpen:
+		if hc.probing {
+			return ErrCircuitOpen
+		}
+		hc.probing = true
+		return nil
+	}
+	return nil
+}
+
+// record counts the outcome of a request to host.
+func (b *Breaker) record(host string, ok bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hc := b.circuit(host)
+	hc.probing = false
+	if ok {
+		if hc.state != circuitClosed {
+			b.logger().Infof("ESPN circuit for %s closed", host)
+		}
+		hc.state = circuitClosed
+		hc.failures = 0
+		return
+	}
+
+	hc.failures++
+	switch {
+	case hc.state == circuitHalfOpen:
+		b.logger().Warnf("ESPN circuit for %s reopened: probe failed", host)
+	case hc.state == circuitClosed && hc.failures >= b.Threshold:
+		b.logger().Warnf("ESPN circuit for %s opened after %d consecutive failures", host, hc.failures)
+	default:
+		return
+	}
+	hc.state = circuitOpen
+	hc.openedAt = time.Now()
+}
+
+// open opens host's circuit at once, whatever its failure count, for a host
+// that asked for a longer pause than the client will wait.
+func (b *Breaker) open(host string, wait time.Duration) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hc := b.circuit(host)
+	hc.probing = false
+	if hc.state != circuitOpen {
+		b.logger().Warnf("ESPN circuit for %s opened: asked to retry after %s", host, wait)
+	}
+	hc.state = circuitOpen
+	hc.openedAt = time.Now()
+}
+
+// release gives up a request to host without an outcome, e.g. one canceled by
+// its caller, so a half-open circuit can send another probe.
+func (b *Breaker) release(host string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.circuit(host).probing = false
+}
+
+func (b *Breaker) logger() *zap.SugaredLogger {
+	if b.Logger == nil {
+		return zap.NewNop().Sugar()
+	}
+	return b.Logger
+}
+
+func (b *Breaker) circuit(host string) *hostCircuit {
+	if b.hosts == nil {
+		b.hosts = map[string]*hostCircuit{}
+	}
+	hc, ok := b.hosts[host]
+	if !ok {
+		hc = &hostCircuit{}
+		b.hosts[host] = hc
+	}
+	return hc
+}
@@ -0,0 +1,302 @@
+package espn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries bounds how many responses a Cache holds in memory.
+const maxCacheEntries = 512
+
+// maxCacheDiskBytes bounds the size of a Cache's disk backing. Past it, the
+// least recently written responses are removed until it is back under three
+// quarters of the bound, so the directory isn't rescanned on every write.
+const maxCacheDiskBytes = 256 << 20
+
+// Cache TTLs by endpoint type. Within its TTL a response is served without a
+// request; after it, the response is revalidated with a conditional request.
+const (
+	// the current schedule, and recent dates', are polled for newly final
+	// games
+	currentScheduleTTL = time.Minute
+	// a season's schedule pages are walked several times per season run
+	datedScheduleTTL = time.Hour
+	teamsTTL         = 6 * time.Hour
+	scoreboardTTL    = time.Hour
+	// box scores are corrected after the fact; always revalidate
+	gameTTL = 0
+)
+
+// ttl returns how long a response for key is served from cache.
+func (k archiveKey) ttl() time.Duration {
+	switch k.endpoint {
+	case endpointSchedule:
+		if k.live {
+			return currentScheduleTTL
+		}
+		return datedScheduleTTL
+	case endpointTeams:
+		return teamsTTL
+	case endpointScoreboard:
+		return scoreboardTTL
+	default:
+		return gameTTL
+	}
+}
+
+// cacheEntry is a cached response body and the validators ESPN sent with it.
+type cacheEntry struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+}
+
+// fresh reports whether the entry can be served without revalidation.
+func (e *cacheEntry) fresh(ttl time.Duration) bool {
+	return time.Since(e.Fetched) < ttl
+}
+
+// Cache holds ESPN response bodies keyed by URL, in memory and optionally on
+// disk. Responses that are always revalidated (a zero TTL) are only kept on
+// disk: the body is needed just when a revalidation comes back 304, so it
+// isn't worth memory, and without a body their validators are of no use. It
+// is safe for concurrent use.
+type Cache struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+
+	diskMu       sync.Mutex
+	maxDiskBytes int64
+	diskBytes    int64
+	measured     bool // diskBytes has been read from dir
+}
+
+// NewCache returns an in-memory response cache. A non-empty dir also keeps
+// responses on disk, up to maxCacheDiskBytes, so later runs can reuse or
+// revalidate them.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir, entries: map[string]*cacheEntry{}, maxDiskBytes: maxCacheDiskBytes}
+}
+
+// get returns the cached response for url, which is served for ttl, or nil.
+// A nil Cache caches nothing.
+func (c *Cache) get(url string, ttl time.Duration) *cacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[url]
+	c.mu.Unlock()
+	if ok || c.dir == "" {
+		return e
+	}
+
+	e, err := c.load(url)
+	if err != nil {
+		// a missing or unreadable entry is a miss; the next response
+		// rewrites it
+		return nil
+	}
+	c.remember(url, e, ttl)
+	return e
+}
+
+// put caches a response for url that is served for ttl. Disk write failures
+// are returned, but the in-memory entry is kept either way.
+func (c *Cache) put(url string, e *cacheEntry, ttl time.Duration) error {
+	if c == nil {
+		return nil
+	}
+
+	c.remember(url, e, ttl)
+
+	if c.dir == "" {
+		return nil
+	}
+	return c.save(url, e)
+}
+
+// remember keeps e in memory unless it is always revalidated.
+func (c *Cache) remember(url string, e *cacheEntry, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.setLocked(url, e)
+	c.mu.Unlock()
+}
+
+// setLocked stores e, evicting the oldest entry when the cache is full.
+func (c *Cache) setLocked(url string, e *cacheEntry) {
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= maxCacheEntries {
+		var oldest string
+		for k, v := range c.entries {
+			if oldest == "" || v.Fetched.Before(c.entries[oldest].Fetched) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[url] = e
+}
+
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) load(url string) (*cacheEntry, error) {
+	data, err := os.ReadFile(c.path(url)) //nolint:gosec // path is a hash under the configured cache dir
+	if err != nil {
+		return nil, err
+	}
+
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (c *Cache) save(url string, e *cacheEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return err
+	}
+
+	c.diskMu.Lock()
+	defer c.diskMu.Unlock()
+
+	if !c.measured {
+		if c.diskBytes, err = c.diskUsage(); err != nil {
+			return err
+		}
+		c.measured = true
+	}
+
+	path := c.path(url)
+	var replaced int64
+	if info, err := os.Stat(path); err == nil {
+		replaced = info.Size()
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	c.diskBytes += int64(len(data)) - replaced
+
+	if c.diskBytes > c.maxDiskBytes {
+		return c.pruneLocked()
+	}
+	return nil
+}
+
+// cacheFile is a response stored in the cache directory.
+type cacheFile struct {
+	path    string
+	size    int64
+	written time.Time
+}
+
+// diskFiles lists the responses stored in the cache directory.
+func (c *Cache) diskFiles() ([]cacheFile, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []cacheFile
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			// removed since the directory was read
+			continue
+		}
+		files = append(files, cacheFile{
+			path:    filepath.Join(c.dir, dirEntry.Name()),
+			size:    info.Size(),
+			written: info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+// diskUsage returns the bytes of responses stored in the cache directory.
+func (c *Cache) diskUsage() (int64, error) {
+	files, err := c.diskFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	return total, nil
+}
+
+// pruneLocked removes the least recently written responses from disk until
+// they take up at most three quarters of maxDiskBytes. A response revalidated
+// since is rewritten, so it counts as recently written. The caller holds
+// diskMu.
+func (c *Cache) pruneLocked() error {
+	files, err := c.diskFiles()
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(files, func(a, b cacheFile) int { return a.written.Compare(b.written) })
+
+	c.diskBytes = 0
+	for _, file := range files {
+		c.diskBytes += file.size
+	}
+	for _, file := range files {
+		if c.diskBytes <= c.maxDiskBytes/4*3 {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		c.diskBytes -= file.size
+	}
+	return nil
+}
+
+// writeFileAtomic writes data through a temporary file in the same directory
+// so a crash never leaves a partial file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -1,6 +1,7 @@
 package espn
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -54,7 +55,7 @@ func (s Sport) Groups() []Group {
 	case CollegeBasketball:
 		return []Group{D1Basketball}
 	case CollegeFootball:
-		return []Group{FBS, FCS}
+		return []Group{FBS, FCS, DII, DIII}
 	default:
 		panic(fmt.Sprintf("unknown sport: %q", s))
 	}
@@ -65,34 +66,31 @@ func (s Sport) HasDivisionSplit() bool {
 	return s == CollegeFootball
 }
 
-func (c *Client) GetCurrentWeekGames(group Group) ([]Game, error) {
-	var games []Game
-
-	url := c.WeekURL() + fmt.Sprintf("&group=%d", group)
+// GetCurrentWeekGames returns every game on the current week's schedule,
+// whatever its status. Use Game.Final to pick out completed games.
+func (c *Client) GetCurrentWeekGames(ctx context.Context, group Group) ([]Game, error) {
+	params := fmt.Sprintf("&group=%d", group)
 
 	var res GameScheduleESPN
-	err := c.makeRequest(url, &res)
+	err := c.makeRequest(ctx, c.WeekURL()+params, scheduleKey(params), &res)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, day := range res.Content.Schedule {
-		for _, event := range day.Games {
-			if event.Status.StatusType.Completed && event.Status.StatusType.Name == "STATUS_FINAL" {
-				games = append(games, event)
-			}
-		}
-	}
-
-	return games, nil
+	return scheduleGames(&res), nil
 }
 
-func (c *Client) GetGamesByWeek(year int64, week int64, group Group, seasonType SeasonType) (*GameScheduleESPN, error) {
-	url := c.WeekURL() +
-		fmt.Sprintf("&year=%d&week=%d&group=%d&seasonType=%d", year, week, group, seasonType)
+func (c *Client) GetGamesByWeek(
+	ctx context.Context,
+	year int64,
+	week int64,
+	group Group,
+	seasonType SeasonType,
+) (*GameScheduleESPN, error) {
+	params := fmt.Sprintf("&year=%d&week=%d&group=%d&seasonType=%d", year, week, group, seasonType)
 
 	var res GameScheduleESPN
-	err := c.makeRequest(url, &res)
+	err := c.makeRequest(ctx, c.WeekURL()+params, scheduleKey(params), &res)
 	if err != nil {
 		return nil, err
 	}
@@ -100,8 +98,14 @@ func (c *Client) GetGamesByWeek(year int64, week int64, group Group, seasonType
 	return &res, nil
 }
 
-func (c *Client) GetCompletedGamesByWeek(year int64, week int64, group Group, seasonType SeasonType) ([]Game, error) {
-	res, err := c.GetGamesByWeek(year, week, group, seasonType)
+func (c *Client) GetCompletedGamesByWeek(
+	ctx context.Context,
+	year int64,
+	week int64,
+	group Group,
+	seasonType SeasonType,
+) ([]Game, error) {
+	res, err := c.GetGamesByWeek(ctx, year, week, group, seasonType)
 	if err != nil {
 		return nil, err
 	}
@@ -111,19 +115,19 @@ func (c *Client) GetCompletedGamesByWeek(year int64, week int64, group Group, se
 
 // GetGamesByDate fetches all games for a specific date (format YYYYMMDD).
 // Used by basketball where the schedule endpoint is date-based, not week-based.
-func (c *Client) GetGamesByDate(date string, group Group) (*GameScheduleESPN, error) {
-	url := c.WeekURL() + fmt.Sprintf("&date=%s&group=%d", date, group)
+func (c *Client) GetGamesByDate(ctx context.Context, date string, group Group) (*GameScheduleESPN, error) {
+	params := fmt.Sprintf("&date=%s&group=%d", date, group)
 
 	var res GameScheduleESPN
-	if err := c.makeRequest(url, &res); err != nil {
+	if err := c.makeRequest(ctx, c.WeekURL()+params, scheduleKey(params), &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
 }
 
 // GetCompletedGamesByDate returns only completed (final) games for a date.
-func (c *Client) GetCompletedGamesByDate(date string, group Group) ([]Game, error) {
-	res, err := c.GetGamesByDate(date, group)
+func (c *Client) GetCompletedGamesByDate(ctx context.Context, date string, group Group) ([]Game, error) {
+	res, err := c.GetGamesByDate(ctx, date, group)
 	if err != nil {
 		return nil, err
 	}
@@ -132,31 +136,37 @@ func (c *Client) GetCompletedGamesByDate(date string, group Group) ([]Game, erro
 
 // GetSeasonDates returns the list of game dates from the scoreboard calendar.
 // Each date is an ISO 8601 timestamp (e.g. "2025-11-03T08:00Z").
-func (c *Client) GetSeasonDates() ([]string, error) {
-	sb, err := c.GetScoreboard()
+func (c *Client) GetSeasonDates(ctx context.Context) ([]string, error) {
+	sb, err := c.GetScoreboard(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return sb.Leagues[0].Calendar, nil
 }
 
-func completedGames(res *GameScheduleESPN) []Game {
+func scheduleGames(res *GameScheduleESPN) []Game {
 	var games []Game
 	for _, day := range res.Content.Schedule {
-		for _, event := range day.Games {
-			if event.Status.StatusType.Completed && event.Status.StatusType.Name == "STATUS_FINAL" {
-				games = append(games, event)
-			}
+		games = append(games, day.Games...)
+	}
+	return games
+}
+
+func completedGames(res *GameScheduleESPN) []Game {
+	var games []Game
+	for _, event := range scheduleGames(res) {
+		if event.Final() {
+			games = append(games, event)
 		}
 	}
 	return games
 }
 
-func (c *Client) GetGameStats(gameID int64) (*GameInfoESPN, error) {
+func (c *Client) GetGameStats(ctx context.Context, gameID int64) (*GameInfoESPN, error) {
 	url := fmt.Sprintf(c.GameStatsURL(), gameID)
 
 	var res GameInfoESPN
-	err := c.makeRequest(url, &res)
+	err := c.makeRequest(ctx, url, gameKey(gameID), &res)
 	if err != nil {
 		return nil, err
 	}
@@ -164,9 +174,9 @@ func (c *Client) GetGameStats(gameID int64) (*GameInfoESPN, error) {
 	return &res, nil
 }
 
-func (c *Client) GetTeamInfo() (*TeamInfoESPN, error) {
+func (c *Client) GetTeamInfo(ctx context.Context) (*TeamInfoESPN, error) {
 	var res TeamInfoESPN
-	err := c.makeRequest(c.TeamInfoURL(), &res)
+	err := c.makeRequest(ctx, c.TeamInfoURL(), latestKey(endpointTeams), &res)
 	if err != nil {
 		return nil, err
 	}
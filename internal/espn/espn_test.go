@@ -1,12 +1,22 @@
 package espn
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func setupTestServer(t *testing.T) *httptest.Server {
@@ -58,7 +68,6 @@ func newTestClient() *FootballClient {
 		MaxRetries:     2,
 		InitialBackoff: 10 * time.Millisecond,
 		RequestTimeout: 1 * time.Second,
-		RateLimit:      0,
 		Sport:          CollegeFootball,
 	}}
 }
@@ -68,25 +77,25 @@ func TestGetCurrentWeekGames(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	games, err := client.GetCurrentWeekGames(FBS)
+	games, err := client.GetCurrentWeekGames(t.Context(), FBS)
 	if err != nil {
 		t.Fatalf("GetCurrentWeekGames: %v", err)
 	}
 
-	// Only STATUS_FINAL games should be returned (2 of 3 in fixture)
-	if len(games) != 2 {
-		t.Fatalf("len(games) = %d, want 2", len(games))
+	// Every game is returned regardless of status (3 in fixture)
+	if len(games) != 3 {
+		t.Fatalf("len(games) = %d, want 3", len(games))
 	}
 
-	ids := map[int64]bool{}
+	final := map[int64]bool{}
 	for _, g := range games {
-		ids[g.ID] = true
+		final[g.ID] = g.Final()
 	}
-	if !ids[1001] || !ids[1002] {
-		t.Errorf("expected game IDs 1001 and 1002, got %v", ids)
+	if !final[1001] || !final[1002] {
+		t.Errorf("expected games 1001 and 1002 to be final, got %v", final)
 	}
-	if ids[1003] {
-		t.Error("in-progress game 1003 should not be included")
+	if final[1003] {
+		t.Error("in-progress game 1003 should not be final")
 	}
 }
 
@@ -95,7 +104,7 @@ func TestGetGamesByWeek(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	res, err := client.GetGamesByWeek(2023, 1, FBS, Regular)
+	res, err := client.GetGamesByWeek(t.Context(), 2023, 1, FBS, Regular)
 	if err != nil {
 		t.Fatalf("GetGamesByWeek: %v", err)
 	}
@@ -116,7 +125,7 @@ func TestGetCompletedGamesByWeek(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	games, err := client.GetCompletedGamesByWeek(2023, 1, FBS, Regular)
+	games, err := client.GetCompletedGamesByWeek(t.Context(), 2023, 1, FBS, Regular)
 	if err != nil {
 		t.Fatalf("GetCompletedGamesByWeek: %v", err)
 	}
@@ -131,7 +140,7 @@ func TestGetWeeksInSeason(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	weeks, err := client.GetWeeksInSeason(2023)
+	weeks, err := client.GetWeeksInSeason(t.Context(), 2023)
 	if err != nil {
 		t.Fatalf("GetWeeksInSeason: %v", err)
 	}
@@ -150,7 +159,7 @@ func TestHasPostseasonStarted(t *testing.T) {
 	// Postseason starts 2023-12-16T08:00Z
 	// Test with time before postseason
 	before := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
-	started, err := client.HasPostseasonStarted(2023, before)
+	started, err := client.HasPostseasonStarted(t.Context(), 2023, before)
 	if err != nil {
 		t.Fatalf("HasPostseasonStarted: %v", err)
 	}
@@ -160,7 +169,7 @@ func TestHasPostseasonStarted(t *testing.T) {
 
 	// Test with time after postseason
 	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-	started, err = client.HasPostseasonStarted(2023, after)
+	started, err = client.HasPostseasonStarted(t.Context(), 2023, after)
 	if err != nil {
 		t.Fatalf("HasPostseasonStarted: %v", err)
 	}
@@ -174,7 +183,7 @@ func TestGetGameStats(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	res, err := client.GetGameStats(1001)
+	res, err := client.GetGameStats(t.Context(), 1001)
 	if err != nil {
 		t.Fatalf("GetGameStats: %v", err)
 	}
@@ -205,7 +214,7 @@ func TestGetTeamInfo(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	res, err := client.GetTeamInfo()
+	res, err := client.GetTeamInfo(t.Context())
 	if err != nil {
 		t.Fatalf("GetTeamInfo: %v", err)
 	}
@@ -233,7 +242,7 @@ func TestDefaultSeason(t *testing.T) {
 	overrideURLs(t, ts.URL)
 	client := newTestClient()
 
-	year, err := client.DefaultSeason()
+	year, err := client.DefaultSeason(t.Context())
 	if err != nil {
 		t.Fatalf("DefaultSeason: %v", err)
 	}
@@ -255,7 +264,7 @@ func TestMakeRequestNon2xx(t *testing.T) {
 	t.Cleanup(restore)
 	client := newTestClient()
 
-	_, err := client.DefaultSeason()
+	_, err := client.DefaultSeason(t.Context())
 	if err == nil {
 		t.Fatal("expected error for 404 response, got nil")
 	}
@@ -277,7 +286,7 @@ func TestMakeRequestMalformedJSON(t *testing.T) {
 	t.Cleanup(restore)
 	client := newTestClient()
 
-	_, err := client.DefaultSeason()
+	_, err := client.DefaultSeason(t.Context())
 	if err == nil {
 		t.Fatal("expected error for malformed JSON, got nil")
 	}
@@ -300,12 +309,487 @@ func TestMakeRequestEmptyResponse(t *testing.T) {
 	client := newTestClient()
 
 	// Empty response fails validation because schedule data is missing.
-	_, err := client.DefaultSeason()
+	_, err := client.DefaultSeason(t.Context())
 	if err == nil {
 		t.Fatal("expected error for empty response, got nil")
 	}
 }
 
+func TestMakeRequestCanceledDuringBackoff(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schedule", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	restore := SetTestURLs(ts.URL+"/schedule", "", "")
+	t.Cleanup(restore)
+	client := newTestClient()
+	client.InitialBackoff = time.Minute
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.DefaultSeason(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("returned after %s, want the backoff wait cut short", elapsed)
+	}
+}
+
+func TestArchivedGameStats(t *testing.T) {
+	ts := setupTestServer(t)
+	overrideURLs(t, ts.URL)
+	client := newTestClient()
+	client.ArchiveDir = t.TempDir()
+
+	if _, err := client.GetGameStats(t.Context(), 1001); err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	if _, err := client.GetGamesByWeek(t.Context(), 2023, 1, FBS, Regular); err != nil {
+		t.Fatalf("GetGamesByWeek: %v", err)
+	}
+
+	schedule := filepath.Join(client.ArchiveDir, "ncaaf", "schedule", "year-2023_week-1_group-80_seasonType-2.json.gz")
+	if _, err := os.Stat(schedule); err != nil {
+		t.Errorf("schedule not archived: %v", err)
+	}
+
+	res, err := ArchivedGameStats(client.ArchiveDir, CollegeFootball, 1001)
+	if err != nil {
+		t.Fatalf("ArchivedGameStats: %v", err)
+	}
+	if res.GamePackage.Header.ID != 1001 {
+		t.Errorf("Header.ID = %d, want 1001", res.GamePackage.Header.ID)
+	}
+
+	if _, err := ArchivedGameStats(client.ArchiveDir, CollegeFootball, 1002); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestArchiveRevalidatedResponses(t *testing.T) {
+	ts, _, notModified := setupCountingServer(t)
+	overrideURLs(t, ts.URL)
+	client := newTestClient()
+	client.ArchiveDir = t.TempDir()
+	client.Cache = NewCache(t.TempDir())
+
+	if _, err := client.GetGameStats(t.Context(), 1001); err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	archived := archivePath(client.ArchiveDir, CollegeFootball, gameKey(1001))
+	if err := os.Remove(archived); err != nil {
+		t.Fatalf("remove archive: %v", err)
+	}
+
+	if _, err := client.GetGameStats(t.Context(), 1001); err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	if notModified.Load() != 1 {
+		t.Fatalf("not modified responses = %d, want 1", notModified.Load())
+	}
+	if _, err := ArchivedGameStats(client.ArchiveDir, CollegeFootball, 1001); err != nil {
+		t.Errorf("revalidated response not archived: %v", err)
+	}
+}
+
+func TestArchiveFailureIsLogged(t *testing.T) {
+	ts := setupTestServer(t)
+	overrideURLs(t, ts.URL)
+	core, logs := observer.New(zap.WarnLevel)
+	client := newTestClient()
+	client.Logger = zap.New(core).Sugar()
+	// a file where the archive directory should be
+	client.ArchiveDir = filepath.Join(t.TempDir(), "archive")
+	if err := os.WriteFile(client.ArchiveDir, nil, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	res, err := client.GetGameStats(t.Context(), 1001)
+	if err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	if res.GamePackage.Header.ID != 1001 {
+		t.Errorf("Header.ID = %d, want 1001", res.GamePackage.Header.ID)
+	}
+	if logs.Len() != 1 {
+		t.Errorf("logged %d warnings, want 1", logs.Len())
+	}
+}
+
+// setupCountingServer serves the schedule and game stats endpoints with an
+// ETag, answering matching conditional requests with 304 Not Modified. It
+// counts full and not-modified responses.
+func setupCountingServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+
+	var full, notModified atomic.Int32
+	serve := func(body any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			full.Add(1)
+			if err := json.NewEncoder(w).Encode(body); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/core/college-football/schedule", serve(testScheduleResponse()))
+	mux.HandleFunc("/core/college-football/playbyplay", serve(testGameInfoResponse()))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts, &full, &notModified
+}
+
+func TestCacheServesFreshResponses(t *testing.T) {
+	ts, full, notModified := setupCountingServer(t)
+	overrideURLs(t, ts.URL)
+	client := newTestClient()
+	client.Cache = NewCache("")
+
+	for range 3 {
+		if _, err := client.DefaultSeason(t.Context()); err != nil {
+			t.Fatalf("DefaultSeason: %v", err)
+		}
+	}
+
+	if full.Load() != 1 || notModified.Load() != 0 {
+		t.Errorf("requests = %d full, %d not modified; want 1, 0", full.Load(), notModified.Load())
+	}
+}
+
+func TestCacheRevalidatesStaleResponses(t *testing.T) {
+	ts, full, notModified := setupCountingServer(t)
+	overrideURLs(t, ts.URL)
+	client := newTestClient()
+	client.Cache = NewCache(t.TempDir())
+
+	// box scores are always revalidated, against the body kept on disk
+	for range 2 {
+		res, err := client.GetGameStats(t.Context(), 1001)
+		if err != nil {
+			t.Fatalf("GetGameStats: %v", err)
+		}
+		if res.GamePackage.Header.ID != 1001 {
+			t.Errorf("Header.ID = %d, want 1001", res.GamePackage.Header.ID)
+		}
+	}
+
+	if full.Load() != 1 || notModified.Load() != 1 {
+		t.Errorf("requests = %d full, %d not modified; want 1, 1", full.Load(), notModified.Load())
+	}
+}
+
+func TestCacheKeepsRevalidatedBodiesOffMemory(t *testing.T) {
+	ts, full, notModified := setupCountingServer(t)
+	overrideURLs(t, ts.URL)
+	client := newTestClient()
+	client.Cache = NewCache("")
+
+	for range 2 {
+		if _, err := client.GetGameStats(t.Context(), 1001); err != nil {
+			t.Fatalf("GetGameStats: %v", err)
+		}
+	}
+
+	// without a disk backing there is no body to revalidate against
+	if full.Load() != 2 || notModified.Load() != 0 {
+		t.Errorf("requests = %d full, %d not modified; want 2, 0", full.Load(), notModified.Load())
+	}
+	if len(client.Cache.entries) != 0 {
+		t.Errorf("memory entries = %d, want 0", len(client.Cache.entries))
+	}
+}
+
+func TestCacheDiskBacking(t *testing.T) {
+	ts, full, _ := setupCountingServer(t)
+	overrideURLs(t, ts.URL)
+	dir := t.TempDir()
+
+	first := newTestClient()
+	first.Cache = NewCache(dir)
+	if _, err := first.DefaultSeason(t.Context()); err != nil {
+		t.Fatalf("DefaultSeason: %v", err)
+	}
+
+	// a new process starts with an empty memory cache
+	second := newTestClient()
+	second.Cache = NewCache(dir)
+	year, err := second.DefaultSeason(t.Context())
+	if err != nil {
+		t.Fatalf("DefaultSeason: %v", err)
+	}
+	if year != 2023 {
+		t.Errorf("year = %d, want 2023", year)
+	}
+
+	if full.Load() != 1 {
+		t.Errorf("full responses = %d, want 1", full.Load())
+	}
+}
+
+func TestCacheDiskBound(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir)
+	entry := &cacheEntry{Body: []byte(`{"padding":"` + strings.Repeat("x", 1000) + `"}`)}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	cache.maxDiskBytes = int64(len(data)) * 10
+
+	urls := make([]string, 12)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+		if err := cache.put(urls[i], entry, time.Hour); err != nil {
+			t.Fatalf("put %d: %v", i, err)
+		}
+		// distinct write times
+		written := time.Now().Add(time.Duration(i-len(urls)) * time.Minute)
+		if err := os.Chtimes(cache.path(urls[i]), written, written); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	// the eleventh write went over ten entries' worth and pruned down to seven
+	// entries, the twelfth stayed under the bound
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read cache dir: %v", err)
+	}
+	if len(files) != 8 {
+		t.Errorf("files = %d, want 8", len(files))
+	}
+	for i, url := range urls {
+		_, err := os.Stat(cache.path(url))
+		if kept := err == nil; kept != (i >= 4) {
+			t.Errorf("entry %d kept = %t, want %t", i, kept, i >= 4)
+		}
+	}
+}
+
+func TestScheduleKeyTTL(t *testing.T) {
+	today := time.Now().Format("20060102")
+	tests := []struct {
+		params string
+		want   time.Duration
+	}{
+		{"", currentScheduleTTL},
+		{"&group=80", currentScheduleTTL},
+		{"&date=" + today + "&group=50", currentScheduleTTL},
+		{"&date=20240115&group=50", datedScheduleTTL},
+		{"&year=2024&week=3&group=80&seasonType=2", datedScheduleTTL},
+	}
+	for _, tt := range tests {
+		if got := scheduleKey(tt.params).ttl(); got != tt.want {
+			t.Errorf("scheduleKey(%q).ttl() = %s, want %s", tt.params, got, tt.want)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	limiter := NewLimiter(20*time.Millisecond, 2)
+
+	start := time.Now()
+	for range 5 {
+		if err := limiter.Wait(t.Context()); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+
+	// the burst of 2 is free; the other 3 wait a token each
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("5 requests took %s, want at least 60ms", elapsed)
+	}
+}
+
+func TestLimiterCanceled(t *testing.T) {
+	limiter := NewLimiter(time.Hour, 1)
+	if err := limiter.Wait(t.Context()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestLimiterShared(t *testing.T) {
+	ts := setupTestServer(t)
+	overrideURLs(t, ts.URL)
+	limiter := NewLimiter(20*time.Millisecond, 1)
+	football := newTestClient()
+	football.Limiter = limiter
+	other := newTestClient()
+	other.Limiter = limiter
+
+	start := time.Now()
+	for range 2 {
+		if _, err := football.GetGameStats(t.Context(), 1001); err != nil {
+			t.Fatalf("GetGameStats: %v", err)
+		}
+		if _, err := other.GetGameStats(t.Context(), 1001); err != nil {
+			t.Fatalf("GetGameStats: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 requests through one limiter took %s, want at least 60ms", elapsed)
+	}
+}
+
+func TestMakeRequestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schedule", func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(testScheduleResponse()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	restore := SetTestURLs(ts.URL+"/schedule", "", "")
+	t.Cleanup(restore)
+	client := newTestClient()
+	// only Retry-After can make the retry prompt
+	client.InitialBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	if _, err := client.DefaultSeason(ctx); err != nil {
+		t.Fatalf("DefaultSeason: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("requests = %d, want 2", calls.Load())
+	}
+}
+
+func TestMakeRequestRetryAfterTooLong(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		timeout    time.Duration
+	}{
+		{name: "beyond max backoff", retryAfter: "3600", timeout: 10 * time.Second},
+		{name: "beyond deadline", retryAfter: "10", timeout: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/schedule", func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Retry-After", tt.retryAfter)
+				http.Error(w, "slow down", http.StatusTooManyRequests)
+			})
+			ts := httptest.NewServer(mux)
+			t.Cleanup(ts.Close)
+
+			restore := SetTestURLs(ts.URL+"/schedule", "", "")
+			t.Cleanup(restore)
+			client := newTestClient()
+			client.Breaker = NewBreaker(nil, DefaultBreakerThreshold, time.Hour)
+
+			ctx, cancel := context.WithTimeout(t.Context(), tt.timeout)
+			defer cancel()
+			start := time.Now()
+			if _, err := client.DefaultSeason(ctx); !errors.Is(err, ErrRetryAfterTooLong) {
+				t.Fatalf("error = %v, want ErrRetryAfterTooLong", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("failing took %s, want no wait", elapsed)
+			}
+			// the host asked for a pause, so its circuit opens on the first failure
+			if _, err := client.DefaultSeason(ctx); !errors.Is(err, ErrCircuitOpen) {
+				t.Errorf("error = %v, want ErrCircuitOpen", err)
+			}
+			if calls.Load() != 1 {
+				t.Errorf("requests = %d, want 1", calls.Load())
+			}
+		})
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	client := newTestClient()
+	client.InitialBackoff = time.Second
+
+	for attempt := range 8 {
+		limit := min(time.Second<<attempt, maxBackoff)
+		for range 100 {
+			if d := client.backoff(attempt); d < 0 || d >= limit {
+				t.Fatalf("backoff(%d) = %s, want in [0, %s)", attempt, d, limit)
+			}
+		}
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schedule", func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(testScheduleResponse()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	restore := SetTestURLs(ts.URL+"/schedule", "", "")
+	t.Cleanup(restore)
+	breaker := NewBreaker(nil, 2, time.Hour)
+	client := newTestClient()
+	client.Breaker = breaker
+
+	// two failed attempts open the circuit
+	if _, err := client.DefaultSeason(t.Context()); err == nil {
+		t.Fatal("expected error from failing server, got nil")
+	}
+	if _, err := client.DefaultSeason(t.Context()); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("error = %v, want ErrCircuitOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("requests = %d, want 2 before the circuit opened", calls.Load())
+	}
+
+	// once cooled down, a successful probe closes the circuit
+	breaker.CoolDown = 0
+	healthy.Store(true)
+	for range 2 {
+		if _, err := client.DefaultSeason(t.Context()); err != nil {
+			t.Fatalf("DefaultSeason: %v", err)
+		}
+	}
+	if calls.Load() != 4 {
+		t.Errorf("requests = %d, want 4", calls.Load())
+	}
+}
+
 func TestGameScheduleValidate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -385,11 +869,11 @@ func TestSportDB(t *testing.T) {
 
 func TestGroups(t *testing.T) {
 	fbGroups := CollegeFootball.Groups()
-	if len(fbGroups) != 2 {
-		t.Fatalf("CollegeFootball.Groups() len = %d, want 2", len(fbGroups))
+	if len(fbGroups) != 4 {
+		t.Fatalf("CollegeFootball.Groups() len = %d, want 4", len(fbGroups))
 	}
-	if fbGroups[0] != FBS || fbGroups[1] != FCS {
-		t.Errorf("CollegeFootball.Groups() = %v, want [FBS, FCS]", fbGroups)
+	if fbGroups[0] != FBS || fbGroups[1] != FCS || fbGroups[2] != DII || fbGroups[3] != DIII {
+		t.Errorf("CollegeFootball.Groups() = %v, want [FBS, FCS, DII, DIII]", fbGroups)
 	}
 
 	bbGroups := CollegeBasketball.Groups()
@@ -537,7 +1021,6 @@ func newBasketballTestClient(t *testing.T, serverURL string) *BasketballClient {
 		MaxRetries:     2,
 		InitialBackoff: 10 * time.Millisecond,
 		RequestTimeout: 1 * time.Second,
-		RateLimit:      0,
 		Sport:          CollegeBasketball,
 		scoreboardURL:  serverURL + "/apis/site/v2/sports/basketball/mens-college-basketball/scoreboard",
 	}}
@@ -547,7 +1030,7 @@ func TestBasketball_DefaultSeason(t *testing.T) {
 	ts := setupBasketballTestServer(t)
 	client := newBasketballTestClient(t, ts.URL)
 
-	year, err := client.DefaultSeason()
+	year, err := client.DefaultSeason(t.Context())
 	if err != nil {
 		t.Fatalf("DefaultSeason: %v", err)
 	}
@@ -560,7 +1043,7 @@ func TestBasketball_GetWeeksInSeason(t *testing.T) {
 	ts := setupBasketballTestServer(t)
 	client := newBasketballTestClient(t, ts.URL)
 
-	weeks, err := client.GetWeeksInSeason(2024)
+	weeks, err := client.GetWeeksInSeason(t.Context(), 2024)
 	if err != nil {
 		t.Fatalf("GetWeeksInSeason: %v", err)
 	}
@@ -576,7 +1059,7 @@ func TestBasketball_HasPostseasonStarted(t *testing.T) {
 	client := newBasketballTestClient(t, ts.URL)
 
 	// Scoreboard fixture has season type 2 (regular), so postseason has not started
-	started, err := client.HasPostseasonStarted(2024, time.Now())
+	started, err := client.HasPostseasonStarted(t.Context(), 2024, time.Now())
 	if err != nil {
 		t.Fatalf("HasPostseasonStarted: %v", err)
 	}
@@ -589,7 +1072,7 @@ func TestBasketball_GetCurrentWeekGames(t *testing.T) {
 	ts := setupBasketballTestServer(t)
 	client := newBasketballTestClient(t, ts.URL)
 
-	games, err := client.GetCurrentWeekGames(D1Basketball)
+	games, err := client.GetCurrentWeekGames(t.Context(), D1Basketball)
 	if err != nil {
 		t.Fatalf("GetCurrentWeekGames: %v", err)
 	}
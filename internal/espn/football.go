@@ -1,6 +1,7 @@
 package espn
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"slices"
@@ -14,9 +15,9 @@ type FootballClient struct{ *Client }
 // Compile-time interface check.
 var _ SportClient = (*FootballClient)(nil)
 
-func (fc *FootballClient) DefaultSeason() (int64, error) {
+func (fc *FootballClient) DefaultSeason(ctx context.Context) (int64, error) {
 	var res GameScheduleESPN
-	err := fc.makeRequest(fc.WeekURL(), &res)
+	err := fc.makeRequest(ctx, fc.WeekURL(), scheduleKey(""), &res)
 	if err != nil {
 		return 0, err
 	}
@@ -24,11 +25,11 @@ func (fc *FootballClient) DefaultSeason() (int64, error) {
 	return res.Content.Defaults.Year, nil
 }
 
-func (fc *FootballClient) GetWeeksInSeason(year int64) (int64, error) {
-	url := fc.WeekURL() + fmt.Sprintf("&year=%d", year)
+func (fc *FootballClient) GetWeeksInSeason(ctx context.Context, year int64) (int64, error) {
+	params := fmt.Sprintf("&year=%d", year)
 
 	var res GameScheduleESPN
-	err := fc.makeRequest(url, &res)
+	err := fc.makeRequest(ctx, fc.WeekURL()+params, scheduleKey(params), &res)
 	if err != nil {
 		return 0, err
 	}
@@ -40,11 +41,11 @@ func (fc *FootballClient) GetWeeksInSeason(year int64) (int64, error) {
 	return int64(len(res.Content.Calendar[0].Weeks)), nil
 }
 
-func (fc *FootballClient) HasPostseasonStarted(year int64, startTime time.Time) (bool, error) {
-	url := fc.WeekURL() + fmt.Sprintf("&year=%d", year)
+func (fc *FootballClient) HasPostseasonStarted(ctx context.Context, year int64, startTime time.Time) (bool, error) {
+	params := fmt.Sprintf("&year=%d", year)
 
 	var res GameScheduleESPN
-	err := fc.makeRequest(url, &res)
+	err := fc.makeRequest(ctx, fc.WeekURL()+params, scheduleKey(params), &res)
 	if err != nil {
 		return false, err
 	}
@@ -59,51 +60,53 @@ func (fc *FootballClient) HasPostseasonStarted(year int64, startTime time.Time)
 	return postSeasonStart.Before(startTime), nil
 }
 
-func (fc *FootballClient) GetGamesBySeason(year int64, group Group) ([]Game, error) {
+// GetGamesBySeason returns every game of a season, including ones not yet
+// played.
+func (fc *FootballClient) GetGamesBySeason(ctx context.Context, year int64, group Group) ([]Game, error) {
 	var allGames []Game
 
-	numWeeks, err := fc.GetWeeksInSeason(year)
+	numWeeks, err := fc.GetWeeksInSeason(ctx, year)
 	if err != nil {
 		return nil, err
 	}
 
 	for i := int64(1); i < numWeeks; i++ {
-		games, err := fc.GetCompletedGamesByWeek(year, i, group, Regular)
+		res, err := fc.GetGamesByWeek(ctx, year, i, group, Regular)
 		if err != nil {
 			return nil, err
 		}
 
-		allGames = append(allGames, games...)
+		allGames = append(allGames, scheduleGames(res)...)
 	}
 
-	games, err := fc.GetCompletedGamesByWeek(year, int64(1), group, Postseason)
+	res, err := fc.GetGamesByWeek(ctx, year, int64(1), group, Postseason)
 	if err != nil {
 		return nil, err
 	}
 
-	allGames = append(allGames, games...)
+	allGames = append(allGames, scheduleGames(res)...)
 
 	return allGames, nil
 }
 
-func (fc *FootballClient) TeamConferencesByYear(year int64) (map[int64]int64, error) {
+func (fc *FootballClient) TeamConferencesByYear(ctx context.Context, year int64) (map[int64]int64, error) {
 	teamConfs := map[int64]int64{}
 
-	numWeeks, err := fc.GetWeeksInSeason(year)
+	numWeeks, err := fc.GetWeeksInSeason(ctx, year)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, group := range fc.Sport.Groups() {
 		for i := int64(1); i < numWeeks; i++ {
-			games, err := fc.GetGamesByWeek(year, i, group, Regular)
+			games, err := fc.GetGamesByWeek(ctx, year, i, group, Regular)
 			if err != nil {
 				return nil, err
 			}
 			maps.Copy(teamConfs, extractTeamConfs(games))
 		}
 
-		games, err := fc.GetGamesByWeek(year, int64(1), group, Postseason)
+		games, err := fc.GetGamesByWeek(ctx, year, int64(1), group, Postseason)
 		if err != nil {
 			return nil, err
 		}
@@ -113,9 +116,9 @@ func (fc *FootballClient) TeamConferencesByYear(year int64) (map[int64]int64, er
 	return teamConfs, nil
 }
 
-func (fc *FootballClient) ConferenceMap() (ConferenceMapResult, error) {
+func (fc *FootballClient) ConferenceMap(ctx context.Context) (ConferenceMapResult, error) {
 	var res GameScheduleESPN
-	err := fc.makeRequest(fc.WeekURL(), &res)
+	err := fc.makeRequest(ctx, fc.WeekURL(), scheduleKey(""), &res)
 	if err != nil {
 		return ConferenceMapResult{}, err
 	}
@@ -126,8 +129,11 @@ func (fc *FootballClient) ConferenceMap() (ConferenceMapResult, error) {
 	fcs := map[int64]string{}
 	dii := []int64{}
 	diii := []int64{}
+	names := map[int64]string{}
+	divisionNames := map[Group]string{} //nolint:exhaustive // filled with DII/DIII names below
 
 	for _, conference := range conferences {
+		names[conference.GroupID] = conference.ShortName
 		switch int64(conference.ParentGroupID) {
 		case int64(FBS):
 			fbs[conference.GroupID] = conference.ShortName
@@ -135,6 +141,7 @@ func (fc *FootballClient) ConferenceMap() (ConferenceMapResult, error) {
 			fcs[conference.GroupID] = conference.ShortName
 		default:
 			if slices.Contains([]int64{int64(DII), int64(DIII)}, conference.GroupID) {
+				divisionNames[Group(conference.GroupID)] = conference.ShortName
 				for _, conf := range conference.SubGroups {
 					group, _ := strconv.ParseInt(conf, 10, 64)
 					switch conference.GroupID {
@@ -150,8 +157,10 @@ func (fc *FootballClient) ConferenceMap() (ConferenceMapResult, error) {
 
 	return ConferenceMapResult{
 		Conferences: map[Group]map[int64]string{ //nolint:exhaustive // football doesn't have D1Basketball
-			FBS: fbs,
-			FCS: fcs,
+			FBS:  fbs,
+			FCS:  fcs,
+			DII:  subGroupNames(dii, names, divisionNames[DII]),
+			DIII: subGroupNames(diii, names, divisionNames[DIII]),
 		},
 		SubGroups: map[Group][]int64{ //nolint:exhaustive // only DII/DIII have sub-groups
 			DII:  dii,
@@ -159,3 +168,17 @@ func (fc *FootballClient) ConferenceMap() (ConferenceMapResult, error) {
 		},
 	}, nil
 }
+
+// subGroupNames maps a division's sub-group IDs to conference names.
+// Conferences the scoreboard doesn't list are named after the division.
+func subGroupNames(groups []int64, names map[int64]string, division string) map[int64]string {
+	confs := map[int64]string{}
+	for _, group := range groups {
+		name, ok := names[group]
+		if !ok {
+			name = division
+		}
+		confs[group] = name
+	}
+	return confs
+}
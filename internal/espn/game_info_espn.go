@@ -13,6 +13,11 @@ type GameInfoESPN struct {
 type GamePackage struct {
 	Header   Header   `json:"header"`
 	Boxscore Boxscore `json:"boxscore"`
+	GameInfo GameInfo `json:"gameInfo"`
+}
+
+type GameInfo struct {
+	Venue Venue `json:"venue"`
 }
 
 type Header struct {
@@ -27,14 +27,33 @@ type Day struct {
 
 type Game struct {
 	ID           int64         `json:"id,string"`
+	Date         string        `json:"date"`
+	Season       Season        `json:"season"`
+	Week         EventWeek     `json:"week"`
 	Status       Status        `json:"status"`
 	Competitions []Competition `json:"competitions"`
 }
 
+// Final reports whether the game has finished and its score is official.
+func (g Game) Final() bool {
+	return g.Status.StatusType.Completed && g.Status.StatusType.Name == "STATUS_FINAL"
+}
+
+type EventWeek struct {
+	Number int64 `json:"number"`
+}
+
 type Competition struct {
+	ConfGame    bool         `json:"conferenceCompetition"`
+	Neutral     bool         `json:"neutralSite"`
+	Venue       Venue        `json:"venue"`
 	Competitors []Competitor `json:"competitors"`
 }
 
+type Venue struct {
+	FullName string `json:"fullName"`
+}
+
 type Competitor struct {
 	ID       int64        `json:"id,string"`
 	Team     ScheduleTeam `json:"team"`
@@ -0,0 +1,68 @@
+package espn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Default request rate shared by every client in a process: a steady two
+// requests a second, with short bursts allowed.
+const (
+	DefaultRequestInterval = 500 * time.Millisecond
+	DefaultRequestBurst    = 5
+)
+
+// Limiter is a token bucket bounding the rate of ESPN requests. One Limiter
+// is meant to be shared by every Client in a process so that concurrent jobs
+// and sports stay under one request rate together. It is safe for concurrent
+// use; a nil Limiter never waits.
+type Limiter struct {
+	interval time.Duration // time to earn one token
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64 // negative when requests are queued for future tokens
+	last   time.Time
+}
+
+// NewLimiter returns a Limiter allowing one request per interval on average
+// and up to burst requests at once.
+func NewLimiter(interval time.Duration, burst int) *Limiter {
+	return &Limiter{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait takes a token, blocking until one is earned or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil || l.interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens * float64(l.interval))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		// hand the token back to the requests still waiting
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -3,20 +3,38 @@ package espn
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 const maxBackoff = 30 * time.Second
 
+// ErrRetryAfterTooLong is returned when a response's Retry-After asks for a
+// longer wait than maxBackoff or the caller's deadline allows.
+var ErrRetryAfterTooLong = errors.New("retry-after exceeds the longest wait")
+
 // Client holds configuration for ESPN HTTP requests.
 type Client struct {
 	MaxRetries     int
 	InitialBackoff time.Duration
 	RequestTimeout time.Duration
-	RateLimit      time.Duration // delay between batch API calls
-	Sport          Sport         // sport this client fetches data for
+	Limiter        *Limiter // request rate limit, shared across clients; nil means none
+	Breaker        *Breaker // per-host circuit breaker, shared across clients; nil means none
+	Sport          Sport    // sport this client fetches data for
+	// ArchiveDir, when non-empty, is where every response body is saved
+	// gzip-compressed, keyed by endpoint and game ID or request parameters.
+	ArchiveDir string
+	// Cache, when non-nil, serves repeated requests for the same URL; see
+	// makeRequest.
+	Cache  *Cache
+	Logger *zap.SugaredLogger // nil discards archive write failures
 
 	// Per-client URL overrides. When non-empty, these take precedence over
 	// the package-level vars. This allows multiple clients (one per sport) to
@@ -35,20 +53,39 @@ func NewClient() SportClient {
 		MaxRetries:     5,
 		InitialBackoff: 1 * time.Second,
 		RequestTimeout: 1 * time.Second,
-		RateLimit:      500 * time.Millisecond,
+		Limiter:        NewLimiter(DefaultRequestInterval, DefaultRequestBurst),
 		Sport:          CollegeFootball,
 	}}
 }
 
-// NewClientForSport returns a SportClient configured for the given sport.
-func NewClientForSport(sport Sport) SportClient {
+// ClientConfig holds the optional shared and on-disk state of a client built by
+// NewClientForSport.
+type ClientConfig struct {
+	ArchiveDir string             // archive every response body here (see Client.ArchiveDir)
+	CacheDir   string             // back the response cache with this directory
+	Limiter    *Limiter           // shared request rate limit; nil gives the client its own
+	Breaker    *Breaker           // shared circuit breaker; nil disables it
+	Logger     *zap.SugaredLogger // logs archive write failures; nil discards them
+}
+
+// NewClientForSport returns a SportClient configured for the given sport, with
+// an in-memory response cache.
+func NewClientForSport(sport Sport, cfg ClientConfig) SportClient {
 	urls := SportURLs(sport)
+	limiter := cfg.Limiter
+	if limiter == nil {
+		limiter = NewLimiter(DefaultRequestInterval, DefaultRequestBurst)
+	}
 	c := &Client{
 		MaxRetries:     5,
 		InitialBackoff: 1 * time.Second,
 		RequestTimeout: 1 * time.Second,
-		RateLimit:      500 * time.Millisecond,
+		Limiter:        limiter,
+		Breaker:        cfg.Breaker,
 		Sport:          sport,
+		ArchiveDir:     cfg.ArchiveDir,
+		Cache:          NewCache(cfg.CacheDir),
+		Logger:         cfg.Logger,
 		scheduleURL:    urls.Schedule,
 		gameStatsURL:   urls.GameStats,
 		teamInfoURL:    urls.TeamInfo,
@@ -69,6 +106,13 @@ func wrapClient(c *Client) SportClient {
 	}
 }
 
+func (c *Client) logger() *zap.SugaredLogger {
+	if c.Logger == nil {
+		return zap.NewNop().Sugar()
+	}
+	return c.Logger
+}
+
 // WeekURL returns the schedule URL for this client.
 func (c *Client) WeekURL() string {
 	if c.scheduleURL != "" {
@@ -110,57 +154,205 @@ type Responses interface {
 	validatable
 }
 
-func (c *Client) makeRequest(endpoint string, data any) error {
+// makeRequest fetches endpoint into data, retrying failed requests (see get).
+// It gives up as soon as ctx is done, including during a backoff wait. With a
+// Cache, a response younger than its key's TTL is served without a request and
+// an older one is revalidated with a conditional request. Valid responses from
+// ESPN, new or revalidated, are archived under key when the client has an
+// ArchiveDir.
+func (c *Client) makeRequest(ctx context.Context, endpoint string, key archiveKey, data any) error {
+	cached := c.Cache.get(endpoint, key.ttl())
+	if cached != nil && cached.fresh(key.ttl()) {
+		return decodeResponse(endpoint, cached.Body, data)
+	}
+
+	res, err := c.get(ctx, endpoint, cached)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && cached != nil {
+		revalidated := *cached
+		revalidated.Fetched = time.Now()
+		if err := c.Cache.put(endpoint, &revalidated, key.ttl()); err != nil {
+			return fmt.Errorf("caching response from %q: %w", endpoint, err)
+		}
+		if err := decodeResponse(endpoint, cached.Body, data); err != nil {
+			return err
+		}
+		c.archiveBody(endpoint, key, cached.Body)
+		return nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from %q", res.StatusCode, endpoint)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading response from %q: %w", endpoint, err)
+	}
+
+	if err := decodeResponse(endpoint, body, data); err != nil {
+		return err
+	}
+
+	c.archiveBody(endpoint, key, body)
+
+	if err := c.Cache.put(endpoint, &cacheEntry{
+		Body:         body,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		Fetched:      time.Now(),
+	}, key.ttl()); err != nil {
+		return fmt.Errorf("caching response from %q: %w", endpoint, err)
+	}
+
+	return nil
+}
+
+// get sends a GET for endpoint, conditional on cached's validators when there
+// is a cached response. Transport errors, 5xx and 429 responses are retried
+// after a jittered backoff, or after the response's Retry-After when it has
+// one. A Retry-After longer than maxBackoff or the time left before ctx's
+// deadline fails at once with ErrRetryAfterTooLong and opens the host's
+// circuit, since the host asked not to be called again that soon. Every
+// attempt waits its turn on the client's Limiter and is refused while the
+// client's Breaker holds the host's circuit open.
+func (c *Client) get(ctx context.Context, endpoint string, cached *cacheEntry) (*http.Response, error) {
 	httpClient := &http.Client{
 		Timeout: c.RequestTimeout,
 	}
-	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 
 	headers := map[string]string{
 		"User-Agent": "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) " +
 			"Chrome/54.0.2840.90 Safari/537.36",
 		"Accept": "application/json",
 	}
+	if cached != nil {
+		if cached.ETag != "" {
+			headers["If-None-Match"] = cached.ETag
+		}
+		if cached.LastModified != "" {
+			headers["If-Modified-Since"] = cached.LastModified
+		}
+	}
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
 
+	host := req.URL.Host
 	var res *http.Response
 	var err error
 	for attempt := range c.MaxRetries {
+		if err = c.Breaker.allow(host); err != nil {
+			break
+		}
+		if waitErr := c.Limiter.Wait(ctx); waitErr != nil {
+			c.Breaker.release(host)
+			return nil, fmt.Errorf("error from %q: %w", endpoint, waitErr)
+		}
+
 		res, err = httpClient.Do(req)
+		if err != nil && ctx.Err() != nil {
+			// the caller gave up; that says nothing about ESPN's health
+			c.Breaker.release(host)
+			return nil, fmt.Errorf("error from %q: %w", endpoint, err)
+		}
+		if err == nil && !retryable(res.StatusCode) {
+			c.Breaker.record(host, true)
+			break
+		}
+		c.Breaker.record(host, false)
+
+		wait := c.backoff(attempt)
 		if err == nil {
-			if res.StatusCode >= 500 {
-				res.Body.Close()
-				err = fmt.Errorf("unexpected status %d from %q", res.StatusCode, endpoint)
-				time.Sleep(c.backoff(attempt))
-				continue
+			res.Body.Close()
+			err = fmt.Errorf("unexpected status %d from %q", res.StatusCode, endpoint)
+			if d, ok := retryAfter(res.Header); ok {
+				if d > maxWait(ctx) {
+					c.Breaker.open(host, d)
+					return nil, fmt.Errorf("status %d from %q, retry after %s: %w",
+						res.StatusCode, endpoint, d, ErrRetryAfterTooLong)
+				}
+				wait = d
 			}
+		}
+		if attempt == c.MaxRetries-1 {
 			break
 		}
-		time.Sleep(c.backoff(attempt))
+		if waitErr := sleep(ctx, wait); waitErr != nil {
+			return nil, fmt.Errorf("error from %q: %w", endpoint, waitErr)
+		}
 	}
 	if err != nil {
-		return fmt.Errorf("error from %q: %w", endpoint, err)
+		return nil, fmt.Errorf("error from %q: %w", endpoint, err)
 	}
 
-	defer res.Body.Close()
+	return res, nil
+}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return fmt.Errorf("unexpected status %d from %q", res.StatusCode, endpoint)
+// retryable reports whether a response status is worth retrying: server
+// errors and rate limiting.
+func retryable(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
 	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
 
-	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+// maxWait returns the longest get waits before a retry: maxBackoff, or less
+// when ctx's deadline is sooner.
+func maxWait(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return min(maxBackoff, time.Until(deadline))
+	}
+	return maxBackoff
+}
+
+// decodeResponse unmarshals a response body into data and validates it.
+func decodeResponse(endpoint string, body []byte, data any) error {
+	if err := json.Unmarshal(body, data); err != nil {
 		return fmt.Errorf("decoding response from %q: %w", endpoint, err)
 	}
 
 	return data.(validatable).validate()
 }
 
+// sleep waits for d, returning early with ctx's error if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff returns a full-jitter wait before the retry after attempt: uniformly
+// random up to InitialBackoff doubled per attempt, capped at maxBackoff, so
+// clients that failed together don't retry together.
 func (c *Client) backoff(attempt int) time.Duration {
-	d := c.InitialBackoff << attempt
-	if d > maxBackoff {
-		return maxBackoff
+	d := min(c.InitialBackoff<<attempt, maxBackoff)
+	if d <= 0 {
+		return 0
 	}
-	return d
+	return rand.N(d) //nolint:gosec // retry jitter needs no secure source
 }
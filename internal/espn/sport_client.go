@@ -1,11 +1,15 @@
 package espn
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // ConferenceMapResult holds conference data returned by ConferenceMap.
 type ConferenceMapResult struct {
 	// Conferences maps group → (conference ID → short name).
-	// Football populates FBS and FCS. Basketball populates D1Basketball.
+	// Football populates FBS, FCS, DII and DIII. Basketball populates
+	// D1Basketball.
 	Conferences map[Group]map[int64]string
 
 	// SubGroups maps group → sub-group IDs. Only used by football (DII, DIII).
@@ -17,28 +21,22 @@ type ConferenceMapResult struct {
 type SportClient interface {
 	// Metadata
 	SportInfo() Sport
-	RateLimitDuration() time.Duration
 
 	// Game data (sport-agnostic)
-	GetCurrentWeekGames(group Group) ([]Game, error)
-	GetGameStats(gameID int64) (*GameInfoESPN, error)
-	GetTeamInfo() (*TeamInfoESPN, error)
+	GetCurrentWeekGames(ctx context.Context, group Group) ([]Game, error)
+	GetGameStats(ctx context.Context, gameID int64) (*GameInfoESPN, error)
+	GetTeamInfo(ctx context.Context) (*TeamInfoESPN, error)
 
 	// Season navigation (sport-specific)
-	DefaultSeason() (int64, error)
-	GetWeeksInSeason(year int64) (int64, error)
-	HasPostseasonStarted(year int64, startTime time.Time) (bool, error)
-	GetGamesBySeason(year int64, group Group) ([]Game, error)
-	TeamConferencesByYear(year int64) (map[int64]int64, error)
-	ConferenceMap() (ConferenceMapResult, error)
+	DefaultSeason(ctx context.Context) (int64, error)
+	GetWeeksInSeason(ctx context.Context, year int64) (int64, error)
+	HasPostseasonStarted(ctx context.Context, year int64, startTime time.Time) (bool, error)
+	GetGamesBySeason(ctx context.Context, year int64, group Group) ([]Game, error)
+	TeamConferencesByYear(ctx context.Context, year int64) (map[int64]int64, error)
+	ConferenceMap(ctx context.Context) (ConferenceMapResult, error)
 }
 
 // SportInfo returns the sport this client is configured for.
 func (c *Client) SportInfo() Sport {
 	return c.Sport
 }
-
-// RateLimitDuration returns the delay between batch API calls.
-func (c *Client) RateLimitDuration() time.Duration {
-	return c.RateLimit
-}
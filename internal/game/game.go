@@ -1,6 +1,8 @@
 package game
 
 import (
+	"context"
+
 	"github.com/robby-barton/stats-go/internal/database"
 	"github.com/robby-barton/stats-go/internal/espn"
 )
@@ -35,12 +37,12 @@ func combineGames(gamesLists [][]espn.Game) []espn.Game {
 	return games
 }
 
-// GetCurrentWeekGames fetches completed games for the current week across all
-// groups defined for the client's sport.
-func GetCurrentWeekGames(client espn.SportClient) ([]espn.Game, error) {
+// GetCurrentWeekGames fetches the current week's games, whatever their status,
+// across all groups defined for the client's sport.
+func GetCurrentWeekGames(ctx context.Context, client espn.SportClient) ([]espn.Game, error) {
 	var allGames [][]espn.Game
 	for _, group := range client.SportInfo().Groups() {
-		games, err := client.GetCurrentWeekGames(group)
+		games, err := client.GetCurrentWeekGames(ctx, group)
 		if err != nil {
 			return nil, err
 		}
@@ -50,12 +52,12 @@ func GetCurrentWeekGames(client espn.SportClient) ([]espn.Game, error) {
 	return combineGames(allGames), nil
 }
 
-// GetGamesForSeason fetches all completed games for a season across all groups
-// defined for the client's sport.
-func GetGamesForSeason(client espn.SportClient, year int64) ([]espn.Game, error) {
+// GetGamesForSeason fetches all games for a season, whatever their status,
+// across all groups defined for the client's sport.
+func GetGamesForSeason(ctx context.Context, client espn.SportClient, year int64) ([]espn.Game, error) {
 	var allGames [][]espn.Game
 	for _, group := range client.SportInfo().Groups() {
-		games, err := client.GetGamesBySeason(year, group)
+		games, err := client.GetGamesBySeason(ctx, year, group)
 		if err != nil {
 			return nil, err
 		}
@@ -65,19 +67,26 @@ func GetGamesForSeason(client espn.SportClient, year int64) ([]espn.Game, error)
 	return combineGames(allGames), nil
 }
 
-func GetSingleGame(client espn.SportClient, gameID int64) (*ParsedGameInfo, error) {
-	res, err := client.GetGameStats(gameID)
+// GetSingleGame fetches a game's stats from ESPN and parses them.
+func GetSingleGame(ctx context.Context, client espn.SportClient, gameID int64) (*ParsedGameInfo, error) {
+	res, err := client.GetGameStats(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
 
+	return ParseGame(client.SportInfo(), res), nil
+}
+
+// ParseGame parses a game stats response, whether freshly fetched or read
+// back from a response archive.
+func ParseGame(sport espn.Sport, res *espn.GameInfoESPN) *ParsedGameInfo {
 	parsedGame := &ParsedGameInfo{}
 	parsedGame.parseGameInfo(res)
-	parsedGame.GameInfo.Sport = client.SportInfo().SportDB()
+	parsedGame.GameInfo.Sport = sport.SportDB()
 	parsedGame.parseTeamInfo(res)
-	if client.SportInfo() == espn.CollegeFootball {
+	if sport == espn.CollegeFootball {
 		parsedGame.parsePlayerStats(res)
 	}
 
-	return parsedGame, nil
+	return parsedGame
 }
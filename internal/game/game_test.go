@@ -167,11 +167,10 @@ func TestGetSingleGame_Basketball(t *testing.T) {
 		MaxRetries:     2,
 		InitialBackoff: 10 * time.Millisecond,
 		RequestTimeout: 1 * time.Second,
-		RateLimit:      0,
 		Sport:          espn.CollegeBasketball,
 	}}
 
-	parsed, err := GetSingleGame(client, 2001)
+	parsed, err := GetSingleGame(t.Context(), client, 2001)
 	if err != nil {
 		t.Fatalf("GetSingleGame: %v", err)
 	}
@@ -217,11 +216,10 @@ func TestGetCurrentWeekGames_Basketball(t *testing.T) {
 		MaxRetries:     2,
 		InitialBackoff: 10 * time.Millisecond,
 		RequestTimeout: 1 * time.Second,
-		RateLimit:      0,
 		Sport:          espn.CollegeBasketball,
 	}}
 
-	games, err := GetCurrentWeekGames(client)
+	games, err := GetCurrentWeekGames(t.Context(), client)
 	if err != nil {
 		t.Fatalf("GetCurrentWeekGames: %v", err)
 	}
@@ -274,7 +272,7 @@ func TestGetSingleGame(t *testing.T) {
 	overrideGameURLs(t, ts.URL)
 
 	client := espn.NewClient()
-	parsed, err := GetSingleGame(client, 1001)
+	parsed, err := GetSingleGame(t.Context(), client, 1001)
 	if err != nil {
 		t.Fatalf("GetSingleGame: %v", err)
 	}
@@ -310,7 +308,7 @@ func TestGetCurrentWeekGames(t *testing.T) {
 	overrideGameURLs(t, ts.URL)
 
 	client := espn.NewClient()
-	games, err := GetCurrentWeekGames(client)
+	games, err := GetCurrentWeekGames(t.Context(), client)
 	if err != nil {
 		t.Fatalf("GetCurrentWeekGames: %v", err)
 	}
@@ -11,13 +11,14 @@ func (s *ParsedGameInfo) parseGameInfo(gameInfo *espn.GameInfoESPN) {
 	var game database.Game
 
 	game.GameID = gameInfo.GamePackage.Header.ID
-	game.StartTime, _ = time.Parse("2006-01-02T15:04Z",
-		gameInfo.GamePackage.Header.Competitions[0].Date)
+	game.StartTime = ParseStartTime(gameInfo.GamePackage.Header.Competitions[0].Date)
 	game.Week = gameInfo.GamePackage.Header.Week
 	game.Season = gameInfo.GamePackage.Header.Season.Year
-	game.Postseason = gameInfo.GamePackage.Header.Season.Type - int64(espn.Regular)
+	game.Postseason = parsePostseason(gameInfo.GamePackage.Header.Season.Type)
 	game.ConfGame = gameInfo.GamePackage.Header.Competitions[0].ConfGame
 	game.Neutral = gameInfo.GamePackage.Header.Competitions[0].Neutral
+	game.Status = ParseStatus(gameInfo.GamePackage.Header.Competitions[0].Status.StatusType)
+	game.Venue = gameInfo.GamePackage.GameInfo.Venue.FullName
 
 	for _, team := range gameInfo.GamePackage.Header.Competitions[0].Competitors {
 		switch team.HomeAway {
@@ -32,3 +33,71 @@ func (s *ParsedGameInfo) parseGameInfo(gameInfo *espn.GameInfoESPN) {
 
 	s.GameInfo = game
 }
+
+// ParseStartTime parses an ESPN event date, returning the zero time if it is
+// malformed.
+func ParseStartTime(date string) time.Time {
+	startTime, _ := time.Parse("2006-01-02T15:04Z", date)
+	return startTime
+}
+
+// parsePostseason maps an ESPN season type to the postseason flag. Preseason
+// games count as regular season.
+func parsePostseason(seasonType int64) int64 {
+	return max(seasonType-int64(espn.Regular), 0)
+}
+
+// ParseStatus maps an ESPN status to a database game status. Statuses other
+// than the known pre-game and off-schedule ones are treated as in progress
+// until ESPN marks the game final.
+func ParseStatus(status espn.StatusType) string {
+	switch status.Name {
+	case "STATUS_FINAL":
+		if status.Completed {
+			return database.GameFinal
+		}
+		return database.GameInProgress
+	case "STATUS_SCHEDULED":
+		return database.GameScheduled
+	case "STATUS_POSTPONED":
+		return database.GamePostponed
+	case "STATUS_CANCELED":
+		return database.GameCanceled
+	default:
+		return database.GameInProgress
+	}
+}
+
+// ParseScheduledGame builds a game row from a schedule entry for a game that
+// is not final yet. Once it is, GetSingleGame's box score replaces the row.
+func ParseScheduledGame(client espn.SportClient, event espn.Game) database.Game {
+	game := database.Game{
+		GameID:     event.ID,
+		Sport:      client.SportInfo().SportDB(),
+		Status:     ParseStatus(event.Status.StatusType),
+		Season:     event.Season.Year,
+		Week:       event.Week.Number,
+		Postseason: parsePostseason(event.Season.Type),
+		StartTime:  ParseStartTime(event.Date),
+	}
+
+	if len(event.Competitions) == 0 {
+		return game
+	}
+	competition := event.Competitions[0]
+	game.ConfGame = competition.ConfGame
+	game.Neutral = competition.Neutral
+	game.Venue = competition.Venue.FullName
+	for _, team := range competition.Competitors {
+		switch team.HomeAway {
+		case "home":
+			game.HomeID = team.ID
+			game.HomeScore = team.Score
+		case "away":
+			game.AwayID = team.ID
+			game.AwayScore = team.Score
+		}
+	}
+
+	return game
+}
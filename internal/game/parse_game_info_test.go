@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/robby-barton/stats-go/internal/database"
 	"github.com/robby-barton/stats-go/internal/espn"
 )
 
@@ -127,3 +128,90 @@ func TestParseGameInfo_Postseason_NeutralSite(t *testing.T) {
 		t.Errorf("AwayScore = %d, want 28", game.AwayScore)
 	}
 }
+
+func TestParseGameInfo_Preseason(t *testing.T) {
+	gameInfo := &espn.GameInfoESPN{
+		GamePackage: espn.GamePackage{
+			Header: espn.Header{
+				ID:     401999998,
+				Season: espn.Season{Year: 2023, Type: int64(espn.Regular) - 1},
+				Competitions: []espn.Competitions{
+					{Date: "2023-08-19T17:00Z"},
+				},
+			},
+		},
+	}
+
+	var s ParsedGameInfo
+	s.parseGameInfo(gameInfo)
+
+	// Preseason games count as regular season here, as in ParseScheduledGame.
+	if s.GameInfo.Postseason != 0 {
+		t.Errorf("Postseason = %d, want 0", s.GameInfo.Postseason)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		status espn.StatusType
+		want   string
+	}{
+		{espn.StatusType{Name: "STATUS_FINAL", Completed: true}, database.GameFinal},
+		{espn.StatusType{Name: "STATUS_FINAL", Completed: false}, database.GameInProgress},
+		{espn.StatusType{Name: "STATUS_SCHEDULED"}, database.GameScheduled},
+		{espn.StatusType{Name: "STATUS_IN_PROGRESS"}, database.GameInProgress},
+		{espn.StatusType{Name: "STATUS_HALFTIME"}, database.GameInProgress},
+		{espn.StatusType{Name: "STATUS_POSTPONED"}, database.GamePostponed},
+		{espn.StatusType{Name: "STATUS_CANCELED", Completed: true}, database.GameCanceled},
+	}
+	for _, tt := range tests {
+		if got := ParseStatus(tt.status); got != tt.want {
+			t.Errorf("ParseStatus(%+v) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestParseScheduledGame(t *testing.T) {
+	client := &espn.FootballClient{Client: &espn.Client{Sport: espn.CollegeFootball}}
+	event := espn.Game{
+		ID:     401555555,
+		Date:   "2023-11-25T20:30Z",
+		Season: espn.Season{Year: 2023, Type: int64(espn.Regular)},
+		Week:   espn.EventWeek{Number: 13},
+		Status: espn.Status{StatusType: espn.StatusType{Name: "STATUS_SCHEDULED"}},
+		Competitions: []espn.Competition{{
+			ConfGame: true,
+			Neutral:  true,
+			Venue:    espn.Venue{FullName: "Mercedes-Benz Stadium"},
+			Competitors: []espn.Competitor{
+				{ID: 200, HomeAway: "away"},
+				{ID: 100, HomeAway: "home"},
+			},
+		}},
+	}
+
+	game := ParseScheduledGame(client, event)
+
+	if game.GameID != 401555555 || game.Sport != espn.SportDBFootball {
+		t.Errorf("GameID/Sport = %d/%q, want 401555555/%q", game.GameID, game.Sport, espn.SportDBFootball)
+	}
+	if game.Status != database.GameScheduled {
+		t.Errorf("Status = %q, want %q", game.Status, database.GameScheduled)
+	}
+	if game.Season != 2023 || game.Week != 13 || game.Postseason != 0 {
+		t.Errorf("season/week/postseason = %d/%d/%d, want 2023/13/0", game.Season, game.Week, game.Postseason)
+	}
+	if game.HomeID != 100 || game.AwayID != 200 {
+		t.Errorf("teams = %d vs %d, want 100 vs 200", game.HomeID, game.AwayID)
+	}
+	if !game.Neutral || !game.ConfGame {
+		t.Errorf("Neutral/ConfGame = %v/%v, want true/true", game.Neutral, game.ConfGame)
+	}
+	if game.Venue != "Mercedes-Benz Stadium" {
+		t.Errorf("Venue = %q, want Mercedes-Benz Stadium", game.Venue)
+	}
+	expectedTime, _ := time.Parse("2006-01-02T15:04Z", "2023-11-25T20:30Z")
+	if !game.StartTime.Equal(expectedTime) {
+		t.Errorf("StartTime = %v, want %v", game.StartTime, expectedTime)
+	}
+}
@@ -0,0 +1,133 @@
+// Package predict turns SRS point ratings into game forecasts.
+package predict
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/ranking"
+)
+
+// Prediction is the forecast for a single game.
+type Prediction struct {
+	GameID      int64 // 0 for a hypothetical matchup
+	HomeID      int64
+	HomeName    string
+	AwayID      int64
+	AwayName    string
+	Neutral     bool
+	Margin      float64 // expected home margin; negative favors the away team
+	HomeWinProb float64
+}
+
+// Predictor forecasts games from a set of point ratings.
+type Predictor struct {
+	Ratings ranking.PointRatings
+}
+
+// NewPredictor fits point ratings with r and returns a Predictor for them.
+func NewPredictor(r *ranking.Ranker) (*Predictor, error) {
+	ratings, err := r.PointRatings()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Predictor{Ratings: ratings}, nil
+}
+
+// Team resolves a team ID or a case-insensitive team name to a rated team ID.
+func (p *Predictor) Team(team string) (int64, error) {
+	if id, err := strconv.ParseInt(team, 10, 64); err == nil {
+		if _, ok := p.Ratings.Ratings[id]; !ok {
+			return 0, fmt.Errorf("team %d is not rated", id)
+		}
+		return id, nil
+	}
+
+	for id, name := range p.Ratings.Names {
+		if strings.EqualFold(name, team) {
+			if _, ok := p.Ratings.Ratings[id]; !ok {
+				return 0, fmt.Errorf("team %q is not rated", team)
+			}
+			return id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown team %q", team)
+}
+
+// Game predicts a game between two rated teams.
+func (p *Predictor) Game(home int64, away int64, neutral bool) (Prediction, error) {
+	homeRating, ok := p.Ratings.Ratings[home]
+	if !ok {
+		return Prediction{}, fmt.Errorf("team %d is not rated", home)
+	}
+	awayRating, ok := p.Ratings.Ratings[away]
+	if !ok {
+		return Prediction{}, fmt.Errorf("team %d is not rated", away)
+	}
+
+	margin := homeRating - awayRating
+	if !neutral {
+		margin += p.Ratings.HomeAdvantage
+	}
+
+	return Prediction{
+		HomeID:      home,
+		HomeName:    p.Ratings.Names[home],
+		AwayID:      away,
+		AwayName:    p.Ratings.Names[away],
+		Neutral:     neutral,
+		Margin:      margin,
+		HomeWinProb: winProbability(margin, p.Ratings.Sigma),
+	}, nil
+}
+
+// Week predicts every stored game of the rating week between two rated teams,
+// whether or not it has been played. Games against teams outside the rated
+// division, and postponed or canceled games, are skipped.
+func (p *Predictor) Week(db *gorm.DB, sport string) ([]Prediction, error) {
+	var games []database.Game
+	if err := db.
+		Where(
+			"sport = ? and season = ? and week = ? and postseason = 0 and status not in (?)",
+			sport, p.Ratings.Year, p.Ratings.Week, []string{database.GamePostponed, database.GameCanceled},
+		).
+		Order("start_time asc").
+		Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	var predictions []Prediction
+	for _, game := range games {
+		prediction, err := p.Game(game.HomeID, game.AwayID, game.Neutral)
+		if err != nil {
+			continue
+		}
+		prediction.GameID = game.GameID
+		predictions = append(predictions, prediction)
+	}
+
+	return predictions, nil
+}
+
+// winProbability treats the actual margin as normally distributed around the
+// expected margin with standard deviation sigma.
+func winProbability(margin float64, sigma float64) float64 {
+	if sigma <= 0 {
+		switch {
+		case margin > 0:
+			return 1
+		case margin < 0:
+			return 0
+		default:
+			return 0.5
+		}
+	}
+	return 0.5 * math.Erfc(-margin/(sigma*math.Sqrt2))
+}
@@ -0,0 +1,117 @@
+package predict
+
+import (
+	"math"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/ranking"
+)
+
+func testPredictor() *Predictor {
+	return &Predictor{Ratings: ranking.PointRatings{
+		Year:          2023,
+		Week:          5,
+		Ratings:       map[int64]float64{1: 10, 2: 3, 3: -5},
+		Names:         map[int64]string{1: "Alpha", 2: "Beta", 3: "Gamma", 4: "Delta"},
+		HomeAdvantage: 2.5,
+		Sigma:         14,
+	}}
+}
+
+func TestWinProbability(t *testing.T) {
+	if got := winProbability(0, 14); got != 0.5 {
+		t.Errorf("winProbability(0) = %f, want 0.5", got)
+	}
+	if got := winProbability(7, 14) + winProbability(-7, 14); math.Abs(got-1) > 1e-12 {
+		t.Errorf("winProbability(7) + winProbability(-7) = %f, want 1", got)
+	}
+	// One sigma favorite wins about 84% of the time.
+	if got := winProbability(14, 14); math.Abs(got-0.8413) > 1e-4 {
+		t.Errorf("winProbability(14) = %f, want 0.8413", got)
+	}
+	if got := winProbability(3, 0); got != 1 {
+		t.Errorf("winProbability with no spread = %f, want 1", got)
+	}
+}
+
+func TestGame(t *testing.T) {
+	p := testPredictor()
+
+	home, err := p.Game(2, 1, false)
+	if err != nil {
+		t.Fatalf("Game: %v", err)
+	}
+	if math.Abs(home.Margin-(-4.5)) > 1e-9 {
+		t.Errorf("home margin = %f, want -4.5", home.Margin)
+	}
+	if home.HomeWinProb >= 0.5 {
+		t.Errorf("home win prob = %f, want < 0.5", home.HomeWinProb)
+	}
+
+	neutral, err := p.Game(2, 1, true)
+	if err != nil {
+		t.Fatalf("Game: %v", err)
+	}
+	if math.Abs(neutral.Margin-(-7)) > 1e-9 {
+		t.Errorf("neutral margin = %f, want -7", neutral.Margin)
+	}
+
+	if _, err := p.Game(1, 4, false); err == nil {
+		t.Error("expected error for unrated team")
+	}
+}
+
+func TestTeam(t *testing.T) {
+	p := testPredictor()
+
+	if id, err := p.Team("beta"); err != nil || id != 2 {
+		t.Errorf("Team(beta) = %d, %v, want 2", id, err)
+	}
+	if id, err := p.Team("3"); err != nil || id != 3 {
+		t.Errorf("Team(3) = %d, %v, want 3", id, err)
+	}
+	if _, err := p.Team("Delta"); err == nil {
+		t.Error("expected error for unrated team")
+	}
+	if _, err := p.Team("Omega"); err == nil {
+		t.Error("expected error for unknown team")
+	}
+}
+
+func TestWeek(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&database.Game{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	games := []database.Game{
+		{GameID: 1, Season: 2023, Week: 5, HomeID: 1, AwayID: 2, Sport: "ncaaf"},
+		{GameID: 2, Season: 2023, Week: 5, HomeID: 3, AwayID: 4, Sport: "ncaaf"},
+		{GameID: 3, Season: 2023, Week: 6, HomeID: 2, AwayID: 3, Sport: "ncaaf"},
+	}
+	if err := db.Create(&games).Error; err != nil {
+		t.Fatalf("seed games: %v", err)
+	}
+
+	predictions, err := testPredictor().Week(db, "ncaaf")
+	if err != nil {
+		t.Fatalf("Week: %v", err)
+	}
+
+	// Game 2 involves unrated Delta and game 3 is the following week.
+	if len(predictions) != 1 || predictions[0].GameID != 1 {
+		t.Fatalf("predictions = %+v, want only game 1", predictions)
+	}
+	if math.Abs(predictions[0].Margin-9.5) > 1e-9 {
+		t.Errorf("margin = %f, want 9.5", predictions[0].Margin)
+	}
+}
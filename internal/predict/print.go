@@ -0,0 +1,38 @@
+//nolint:forbidigo // predict doesn't have a logger
+package predict
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// PrintPredictions writes predictions as a table along with the rating week
+// they were made from.
+func (p *Predictor) PrintPredictions(predictions []Prediction) {
+	fmt.Printf("%d Week %d\n", p.Ratings.Year, p.Ratings.Week)
+	fmt.Printf("Home edge %.1f, margin sigma %.1f\n", p.Ratings.HomeAdvantage, p.Ratings.Sigma)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Home", "Away", "Site", "Favorite", "Margin", "Win %"})
+	for _, pred := range predictions {
+		site := "Home"
+		if pred.Neutral {
+			site = "Neutral"
+		}
+
+		favorite, margin, prob := pred.HomeName, pred.Margin, pred.HomeWinProb
+		if pred.Margin < 0 {
+			favorite, margin, prob = pred.AwayName, -pred.Margin, 1-pred.HomeWinProb
+		}
+
+		t.AppendRow(table.Row{
+			pred.HomeName, pred.AwayName, site, favorite,
+			fmt.Sprintf("%.1f", margin), fmt.Sprintf("%.1f", prob*100),
+		})
+	}
+	t.Render()
+}
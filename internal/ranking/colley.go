@@ -0,0 +1,99 @@
+package ranking
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// colleyModel rates teams with the Colley Matrix method. It only looks at wins
+// and losses between division-mates, which makes it a record-only baseline to
+// compare against the margin-based composite.
+type colleyModel struct{}
+
+func (colleyModel) Name() string {
+	return "colley"
+}
+
+func (colleyModel) Rate(r *Ranker, teamList TeamList) error {
+	if err := r.record(teamList); err != nil {
+		return err
+	}
+
+	if err := r.colley(teamList); err != nil {
+		return err
+	}
+
+	rankFinal(teamList)
+
+	return nil
+}
+
+// colley solves the Colley system C * x = b, where C has 2 plus the games
+// played on the diagonal and minus the games between each pair of teams off
+// it, and b is 1 + (wins - losses) / 2. Ties count as games played but not
+// as wins or losses. Each team's rating is stored as its FinalRaw.
+func (r *Ranker) colley(teamList TeamList) error {
+	// range order over a map is not deterministic, so create a slice to ensure
+	// order when creating vectors/matrices for SoE
+	var teamOrder []int64
+	for id := range teamList {
+		teamOrder = append(teamOrder, id)
+	}
+	slices.Sort(teamOrder)
+	if len(teamOrder) == 0 {
+		return nil
+	}
+
+	teamOrderMap := map[int64]int{}
+	for idx, team := range teamOrder {
+		teamOrderMap[team] = idx
+	}
+
+	gameList, err := r.sosGames(teamList)
+	if err != nil {
+		return err
+	}
+
+	numTeams := len(teamOrder)
+	c := mat.NewSymDense(numTeams, nil)
+	b := mat.NewVecDense(numTeams, nil)
+	for i := range numTeams {
+		c.SetSym(i, i, 2)
+		b.SetVec(i, 1)
+	}
+
+	for _, game := range gameList {
+		home, away := teamOrderMap[game.HomeID], teamOrderMap[game.AwayID]
+		c.SetSym(home, home, c.At(home, home)+1)
+		c.SetSym(away, away, c.At(away, away)+1)
+		c.SetSym(home, away, c.At(home, away)-1)
+
+		switch {
+		case game.HomeScore > game.AwayScore:
+			b.SetVec(home, b.AtVec(home)+0.5)
+			b.SetVec(away, b.AtVec(away)-0.5)
+		case game.AwayScore > game.HomeScore:
+			b.SetVec(home, b.AtVec(home)-0.5)
+			b.SetVec(away, b.AtVec(away)+0.5)
+		}
+	}
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(c); !ok {
+		return errors.New("colley matrix is not positive definite")
+	}
+
+	var x mat.VecDense
+	if err := chol.SolveVecTo(&x, b); err != nil {
+		return fmt.Errorf("colley matrix is near singular: (%w)", err)
+	}
+
+	for idx, team := range teamOrder {
+		teamList[team].FinalRaw = x.AtVec(idx)
+	}
+
+	return nil
+}
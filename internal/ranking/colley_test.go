@@ -0,0 +1,86 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+func TestColley_TwoTeams(t *testing.T) {
+	db := setupTestDB(t)
+	games := []database.Game{
+		{
+			GameID: 1, Season: 2023, HomeID: 1, AwayID: 2, HomeScore: 21, AwayScore: 20,
+			Sport: "ncaaf", StartTime: time.Date(2023, 9, 2, 19, 0, 0, 0, time.UTC),
+		},
+	}
+	if err := db.Create(&games).Error; err != nil {
+		t.Fatalf("seed games: %v", err)
+	}
+
+	r := &Ranker{
+		DB:        db,
+		Year:      2023,
+		Sport:     sportFootball,
+		startTime: time.Date(2023, 9, 5, 0, 0, 0, 0, time.UTC),
+	}
+	teamList := TeamList{1: &Team{}, 2: &Team{}}
+
+	if err := r.colley(teamList); err != nil {
+		t.Fatalf("colley: %v", err)
+	}
+
+	// [3 -1; -1 3] x = [1.5; 0.5]
+	if math.Abs(teamList[1].FinalRaw-0.625) > 1e-9 {
+		t.Errorf("winner rating = %f, want 0.625", teamList[1].FinalRaw)
+	}
+	if math.Abs(teamList[2].FinalRaw-0.375) > 1e-9 {
+		t.Errorf("loser rating = %f, want 0.375", teamList[2].FinalRaw)
+	}
+}
+
+func TestCalculateRanking_Colley(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{
+		DB:    db,
+		Year:  2023,
+		Sport: sportFootball,
+		Model: "colley",
+	}
+
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	// FCS Epsilon is left out, as are its games against Alpha and Beta.
+	if len(teamList) != 4 {
+		t.Fatalf("len(teamList) = %d, want 4", len(teamList))
+	}
+
+	// Colley ratings always average 1/2.
+	var total float64
+	for _, team := range teamList {
+		total += team.FinalRaw
+	}
+	if avg := total / float64(len(teamList)); math.Abs(avg-0.5) > 1e-9 {
+		t.Errorf("average rating = %f, want 0.5", avg)
+	}
+
+	// Alpha (3-0 in division) should be rank 1, Delta (0-3-1) last.
+	if teamList[1].FinalRank != 1 {
+		t.Errorf("Alpha FinalRank = %d, want 1", teamList[1].FinalRank)
+	}
+	if teamList[4].FinalRank != 4 {
+		t.Errorf("Delta FinalRank = %d, want 4", teamList[4].FinalRank)
+	}
+
+	// The record shown alongside the rating still includes FCS games.
+	if teamList[1].Record.Wins != 4 {
+		t.Errorf("Alpha wins = %d, want 4", teamList[1].Record.Wins)
+	}
+}
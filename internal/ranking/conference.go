@@ -0,0 +1,134 @@
+package ranking
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ConferenceStrength aggregates the ranked teams of one conference.
+type ConferenceStrength struct {
+	Conf       string
+	Year       int64
+	Week       int64
+	Postseason int64
+	Teams      int64
+	Rank       int64 // by MeanRaw
+	MeanRaw    float64
+	MedianRaw  float64
+	// Depth is the mean FinalRaw of the conference's lower half, so only a
+	// conference whose weaker teams are strong too is deep.
+	Depth     float64
+	BestRank  int64 // best FinalRank of its teams
+	WorstRank int64 // worst FinalRank of its teams
+	// NonConf is the record of its teams against teams of other conferences,
+	// in the division or not.
+	NonConf Record
+}
+
+// Conferences aggregates teamList, the Ranker's CalculateRanking result, by
+// conference, strongest first.
+func (r *Ranker) Conferences(teamList TeamList) ([]ConferenceStrength, error) {
+	if len(teamList) == 0 {
+		return nil, nil
+	}
+
+	games, err := r.rankingGames(gameQuery{
+		sport:     r.sportFilter(),
+		minSeason: r.Year,
+		maxSeason: r.Year,
+		before:    r.startTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nonConf := map[string]*Record{}
+	raws := map[string][]float64{}
+	conferences := map[string]*ConferenceStrength{}
+	for _, team := range teamList {
+		conf, ok := conferences[team.Conf]
+		if !ok {
+			conf = &ConferenceStrength{
+				Conf:       team.Conf,
+				Year:       team.Year,
+				Week:       team.Week,
+				Postseason: team.Postseason,
+				BestRank:   team.FinalRank,
+			}
+			conferences[team.Conf] = conf
+			nonConf[team.Conf] = &Record{}
+		}
+		conf.Teams++
+		conf.BestRank = min(conf.BestRank, team.FinalRank)
+		conf.WorstRank = max(conf.WorstRank, team.FinalRank)
+		raws[team.Conf] = append(raws[team.Conf], team.FinalRaw)
+	}
+
+	for _, game := range games {
+		home, away := teamList[game.HomeID], teamList[game.AwayID]
+		if home != nil && away != nil && home.Conf == away.Conf {
+			continue
+		}
+		if home != nil {
+			addResult(nonConf[home.Conf], game.HomeScore, game.AwayScore)
+		}
+		if away != nil {
+			addResult(nonConf[away.Conf], game.AwayScore, game.HomeScore)
+		}
+	}
+
+	var result []ConferenceStrength
+	for name, conf := range conferences {
+		values := raws[name]
+		slices.Sort(values)
+
+		var total float64
+		for _, raw := range values {
+			total += raw
+		}
+		conf.MeanRaw = total / float64(len(values))
+
+		n := len(values)
+		if n%2 == 1 {
+			conf.MedianRaw = values[n/2]
+		} else {
+			conf.MedianRaw = (values[n/2-1] + values[n/2]) / 2
+		}
+
+		var lower float64
+		for _, raw := range values[:(n+1)/2] {
+			lower += raw
+		}
+		conf.Depth = lower / float64((n+1)/2)
+
+		record := nonConf[name]
+		record.Record = recordScore(record.Wins, record.Losses, record.Ties)
+		conf.NonConf = *record
+
+		result = append(result, *conf)
+	}
+
+	slices.SortFunc(result, func(a, b ConferenceStrength) int {
+		if c := cmp.Compare(b.MeanRaw, a.MeanRaw); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Conf, b.Conf)
+	})
+	for i := range result {
+		result[i].Rank = int64(i + 1)
+	}
+
+	return result, nil
+}
+
+// addResult adds one game, scored from the record holder's side, to record.
+func addResult(record *Record, score int64, oppScore int64) {
+	switch {
+	case score > oppScore:
+		record.Wins++
+	case score < oppScore:
+		record.Losses++
+	default:
+		record.Ties++
+	}
+}
@@ -0,0 +1,68 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConferences(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball}
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	confs, err := r.Conferences(teamList)
+	if err != nil {
+		t.Fatalf("Conferences: %v", err)
+	}
+
+	if len(confs) != 2 {
+		t.Fatalf("len(confs) = %d, want 2", len(confs))
+	}
+	byName := map[string]ConferenceStrength{}
+	for i, conf := range confs {
+		byName[conf.Conf] = conf
+		if conf.Rank != int64(i+1) {
+			t.Errorf("%s Rank = %d, want %d", conf.Conf, conf.Rank, i+1)
+		}
+		if i > 0 && conf.MeanRaw > confs[i-1].MeanRaw {
+			t.Errorf("conferences not ordered by mean: %+v", confs)
+		}
+	}
+
+	sec, bigTen := byName["SEC"], byName["Big Ten"]
+	if sec.Rank != 1 {
+		t.Errorf("SEC Rank = %d, want 1", sec.Rank)
+	}
+
+	// The Alpha-Beta and Gamma-Delta games are conference games; the FCS
+	// games count for the SEC.
+	if sec.NonConf.Wins != 6 || sec.NonConf.Losses != 1 || sec.NonConf.Ties != 0 {
+		t.Errorf("SEC non-conf = %v, want 6-1", sec.NonConf)
+	}
+	if bigTen.NonConf.Wins != 1 || bigTen.NonConf.Losses != 4 {
+		t.Errorf("Big Ten non-conf = %v, want 1-4", bigTen.NonConf)
+	}
+
+	alpha, beta := teamList[1], teamList[2]
+	if sec.Teams != 2 {
+		t.Errorf("SEC Teams = %d, want 2", sec.Teams)
+	}
+	if want := (alpha.FinalRaw + beta.FinalRaw) / 2; math.Abs(sec.MeanRaw-want) > 1e-12 ||
+		math.Abs(sec.MedianRaw-want) > 1e-12 {
+		t.Errorf("SEC mean/median = %f/%f, want %f", sec.MeanRaw, sec.MedianRaw, want)
+	}
+	// The lower half of two teams is the weaker one.
+	if want := math.Min(alpha.FinalRaw, beta.FinalRaw); sec.Depth != want {
+		t.Errorf("SEC Depth = %f, want %f", sec.Depth, want)
+	}
+	if sec.BestRank != min(alpha.FinalRank, beta.FinalRank) || sec.WorstRank != max(alpha.FinalRank, beta.FinalRank) {
+		t.Errorf("SEC ranks = %d-%d", sec.BestRank, sec.WorstRank)
+	}
+	if sec.Year != 2023 || sec.Week != r.Week {
+		t.Errorf("SEC year/week = %d/%d, want 2023/%d", sec.Year, sec.Week, r.Week)
+	}
+}
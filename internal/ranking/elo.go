@@ -0,0 +1,256 @@
+package ranking
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+type eloParams struct {
+	Initial       float64 // rating of a team in its first game
+	K             float64 // largest possible rating change from one game
+	MOVFactor     float64 // scales the margin-of-victory multiplier; 0 disables it
+	HomeAdvantage float64 // rating points added to the home team at non-neutral sites
+	Regression    float64 // fraction of the way back to Initial between seasons
+}
+
+// eloModel ranks teams by Elo rating. Unlike SRS, Elo carries over from one
+// season to the next, so it needs no backfill of previous-season games.
+type eloModel struct{}
+
+func (eloModel) Name() string {
+	return "elo"
+}
+
+func (eloModel) Rate(r *Ranker, teamList TeamList) error {
+	if len(r.Hypothetical) > 0 {
+		// ratings come from stored history, which hypothetical games never reach
+		return errors.New("the elo model does not support hypothetical games")
+	}
+
+	if err := r.record(teamList); err != nil {
+		return err
+	}
+
+	if err := r.elo(teamList); err != nil {
+		return err
+	}
+
+	rankFinal(teamList)
+
+	return nil
+}
+
+// eloState is a team's rating after the last game it played.
+type eloState struct {
+	rating float64
+	season int64
+}
+
+// eloRater walks games in start time order, updating each team's rating.
+type eloRater struct {
+	params eloParams
+	teams  map[int64]eloState
+}
+
+func newEloRater(params eloParams, teams map[int64]eloState) *eloRater {
+	if teams == nil {
+		teams = map[int64]eloState{}
+	}
+	return &eloRater{params: params, teams: teams}
+}
+
+// rating returns a team's rating going into a game in season, regressed toward
+// the initial rating if the team last played in an earlier season.
+func (e *eloRater) rating(team int64, season int64) float64 {
+	state, ok := e.teams[team]
+	if !ok {
+		return e.params.Initial
+	}
+	if state.season < season {
+		return state.rating + e.params.Regression*(e.params.Initial-state.rating)
+	}
+	return state.rating
+}
+
+// apply updates both teams' ratings for a completed game and returns their
+// history rows.
+func (e *eloRater) apply(game database.Game, sport string) []database.EloHistory {
+	homePre := e.rating(game.HomeID, game.Season)
+	awayPre := e.rating(game.AwayID, game.Season)
+
+	diff := homePre - awayPre
+	if !game.Neutral {
+		diff += e.params.HomeAdvantage
+	}
+	expected := 1 / (1 + math.Pow(10, -diff/400))
+
+	actual := 0.5
+	mult := 1.0
+	margin := game.HomeScore - game.AwayScore
+	switch {
+	case margin > 0:
+		actual = 1
+		mult = e.movMultiplier(margin, diff)
+	case margin < 0:
+		actual = 0
+		mult = e.movMultiplier(-margin, -diff)
+	}
+
+	delta := e.params.K * mult * (actual - expected)
+	homePost := homePre + delta
+	awayPost := awayPre - delta
+
+	e.teams[game.HomeID] = eloState{rating: homePost, season: game.Season}
+	e.teams[game.AwayID] = eloState{rating: awayPost, season: game.Season}
+
+	return []database.EloHistory{
+		{
+			GameID: game.GameID, TeamID: game.HomeID, Sport: sport, Season: game.Season,
+			StartTime: game.StartTime, PreRating: homePre, PostRating: homePost,
+		},
+		{
+			GameID: game.GameID, TeamID: game.AwayID, Sport: sport, Season: game.Season,
+			StartTime: game.StartTime, PreRating: awayPre, PostRating: awayPost,
+		},
+	}
+}
+
+// movMultiplier grows with the margin of victory but shrinks when the winner
+// was already favored, so heavy favorites don't run up their ratings.
+func (e *eloRater) movMultiplier(margin int64, winnerDiff float64) float64 {
+	if e.params.MOVFactor == 0 {
+		return 1
+	}
+	return math.Log(float64(margin)+1) * e.params.MOVFactor / (winnerDiff*0.001 + e.params.MOVFactor)
+}
+
+// loadEloHistory reads the history rows tx selects, oldest first.
+func loadEloHistory(tx *gorm.DB) ([]database.EloHistory, error) {
+	var history []database.EloHistory
+	if err := tx.Order("start_time asc, game_id asc").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// latestEloState records in teams each team's rating after its latest game in
+// history, which is oldest first.
+func latestEloState(teams map[int64]eloState, history []database.EloHistory) {
+	for _, row := range history {
+		teams[row.TeamID] = eloState{rating: row.PostRating, season: row.Season}
+	}
+}
+
+// loadEloState returns each team's latest entry in the history rows tx
+// selects.
+func loadEloState(tx *gorm.DB) (map[int64]eloState, error) {
+	history, err := loadEloHistory(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := map[int64]eloState{}
+	latestEloState(teams, history)
+	return teams, nil
+}
+
+// elo sets each team's FinalRaw to its Elo rating going into r.Year as of
+// r.startTime. Ratings come from the elo_history table through the Ranker's
+// game source; if the sport has no history yet they are computed from the
+// games directly.
+func (r *Ranker) elo(teamList TeamList) error {
+	sport := r.sportFilter()
+	teams, err := r.gameSource().eloRatings(sport, r.startTime)
+	if err != nil {
+		return err
+	}
+
+	rater := newEloRater(r.sportConfig().Elo, teams)
+	if teams == nil {
+		games, err := r.rankingGames(gameQuery{sport: sport, before: r.startTime})
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(games, func(a, b database.Game) int {
+			return cmp.Or(a.StartTime.Compare(b.StartTime), cmp.Compare(a.GameID, b.GameID))
+		})
+		for _, game := range games {
+			rater.apply(game, sport)
+		}
+	}
+
+	for id, team := range teamList {
+		team.FinalRaw = rater.rating(id, r.Year)
+	}
+
+	return nil
+}
+
+// UpdateEloHistory recomputes the elo_history rows for every game of the
+// Ranker's sport that started at or after from, carrying forward each team's
+// rating from before it. Passing the start time of newly added games applies
+// only those games; a back-dated change replays everything after it. If the
+// sport has no history yet, the full history is built.
+func (r *Ranker) UpdateEloHistory(from time.Time) (int, error) {
+	sport := r.sportFilter()
+
+	var historyRows int64
+	if err := r.DB.Model(&database.EloHistory{}).
+		Where("sport = ?", sport).
+		Count(&historyRows).Error; err != nil {
+		return 0, err
+	}
+	if historyRows == 0 {
+		from = time.Time{}
+	}
+
+	teams, err := loadEloState(r.DB.Where("sport = ? and start_time < ?", sport, from))
+	if err != nil {
+		return 0, err
+	}
+
+	var games []database.Game
+	if err := r.finalGames().
+		Where("sport = ? and start_time >= ?", sport, from).
+		Order("start_time asc, game_id asc").
+		Find(&games).Error; err != nil {
+		return 0, err
+	}
+
+	rater := newEloRater(r.sportConfig().Elo, teams)
+	var history []database.EloHistory
+	for _, game := range games {
+		history = append(history, rater.apply(game, sport)...)
+	}
+
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("sport = ? and start_time >= ?", sport, from).
+			Delete(&database.EloHistory{}).Error; err != nil {
+			return err
+		}
+
+		if len(history) == 0 {
+			return nil
+		}
+
+		return tx.
+			Clauses(clause.OnConflict{
+				UpdateAll: true, // upsert
+			}).
+			CreateInBatches(history, 1000).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(games), nil
+}
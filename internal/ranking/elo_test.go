@@ -0,0 +1,144 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+func TestEloRater_Apply(t *testing.T) {
+	params := eloParams{Initial: 1500, K: 25, MOVFactor: 2.2, HomeAdvantage: 55, Regression: 0.4}
+	rater := newEloRater(params, nil)
+
+	history := rater.apply(database.Game{
+		GameID: 1, Season: 2023, HomeID: 1, AwayID: 2, HomeScore: 17, AwayScore: 7, Neutral: true,
+	}, sportFootball)
+
+	// Even teams at a neutral site: expected 0.5, multiplier ln(11).
+	want := 25 * math.Log(11) * 0.5
+	if math.Abs(history[0].PostRating-(1500+want)) > 1e-9 {
+		t.Errorf("winner post rating = %f, want %f", history[0].PostRating, 1500+want)
+	}
+	if math.Abs(history[1].PostRating-(1500-want)) > 1e-9 {
+		t.Errorf("loser post rating = %f, want %f", history[1].PostRating, 1500-want)
+	}
+	if history[0].PreRating != 1500 || history[1].PreRating != 1500 {
+		t.Errorf("pre ratings = %f, %f, want 1500", history[0].PreRating, history[1].PreRating)
+	}
+}
+
+func TestEloRater_HomeAdvantage(t *testing.T) {
+	params := eloParams{Initial: 1500, K: 25, HomeAdvantage: 55}
+	rater := newEloRater(params, nil)
+
+	history := rater.apply(database.Game{
+		GameID: 1, Season: 2023, HomeID: 1, AwayID: 2, HomeScore: 7, AwayScore: 7,
+	}, sportFootball)
+
+	// A home tie between even teams is a disappointment for the home team.
+	if history[0].PostRating >= 1500 {
+		t.Errorf("home post rating = %f, want < 1500", history[0].PostRating)
+	}
+	if sum := history[0].PostRating + history[1].PostRating; math.Abs(sum-3000) > 1e-9 {
+		t.Errorf("rating sum = %f, want 3000", sum)
+	}
+}
+
+func TestEloRater_Regression(t *testing.T) {
+	params := eloParams{Initial: 1500, Regression: 0.4}
+	rater := newEloRater(params, map[int64]eloState{1: {rating: 1600, season: 2022}})
+
+	if got := rater.rating(1, 2022); got != 1600 {
+		t.Errorf("same-season rating = %f, want 1600", got)
+	}
+	if got := rater.rating(1, 2023); math.Abs(got-1560) > 1e-9 {
+		t.Errorf("next-season rating = %f, want 1560", got)
+	}
+	if got := rater.rating(2, 2023); got != 1500 {
+		t.Errorf("new team rating = %f, want 1500", got)
+	}
+}
+
+func TestUpdateEloHistory_Incremental(t *testing.T) {
+	full := setupTestDB(t)
+	seedTestData(t, full)
+	r := &Ranker{DB: full, Sport: sportFootball}
+	if _, err := r.UpdateEloHistory(time.Time{}); err != nil {
+		t.Fatalf("full UpdateEloHistory: %v", err)
+	}
+
+	// Apply the same games in two passes: everything before week 3, then the rest.
+	incremental := setupTestDB(t)
+	seedTestData(t, incremental)
+	cutoff := time.Date(2023, 9, 19, 19, 0, 0, 0, time.UTC)
+	var later []database.Game
+	if err := incremental.Where("start_time >= ?", cutoff).Find(&later).Error; err != nil {
+		t.Fatalf("query games: %v", err)
+	}
+	if err := incremental.Delete(&later).Error; err != nil {
+		t.Fatalf("delete games: %v", err)
+	}
+	r = &Ranker{DB: incremental, Sport: sportFootball}
+	if _, err := r.UpdateEloHistory(time.Time{}); err != nil {
+		t.Fatalf("first UpdateEloHistory: %v", err)
+	}
+	if err := incremental.Create(&later).Error; err != nil {
+		t.Fatalf("reinsert games: %v", err)
+	}
+	applied, err := r.UpdateEloHistory(cutoff)
+	if err != nil {
+		t.Fatalf("second UpdateEloHistory: %v", err)
+	}
+	if applied != len(later) {
+		t.Errorf("applied = %d, want %d", applied, len(later))
+	}
+
+	var want, got []database.EloHistory
+	full.Order("game_id, team_id").Find(&want)
+	incremental.Order("game_id, team_id").Find(&got)
+	if len(want) != 24 || len(got) != len(want) {
+		t.Fatalf("history rows = %d and %d, want 24", len(want), len(got))
+	}
+	for i := range want {
+		if math.Abs(want[i].PostRating-got[i].PostRating) > 1e-9 {
+			t.Errorf("game %d team %d post rating = %f, want %f",
+				got[i].GameID, got[i].TeamID, got[i].PostRating, want[i].PostRating)
+		}
+	}
+}
+
+func TestCalculateRanking_Elo(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball, Model: "elo"}
+	computed, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking without history: %v", err)
+	}
+
+	if _, err := r.UpdateEloHistory(time.Time{}); err != nil {
+		t.Fatalf("UpdateEloHistory: %v", err)
+	}
+	r = &Ranker{DB: db, Year: 2023, Sport: sportFootball, Model: "elo"}
+	stored, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking with history: %v", err)
+	}
+
+	if len(stored) != 4 {
+		t.Fatalf("len(teamList) = %d, want 4", len(stored))
+	}
+	for id, team := range stored {
+		if math.Abs(team.FinalRaw-computed[id].FinalRaw) > 1e-9 {
+			t.Errorf("team %d rating = %f from history, %f computed", id, team.FinalRaw, computed[id].FinalRaw)
+		}
+	}
+
+	// Alpha is unbeaten across both seasons.
+	if stored[1].FinalRank != 1 {
+		t.Errorf("Alpha FinalRank = %d, want 1", stored[1].FinalRank)
+	}
+}
@@ -0,0 +1,205 @@
+package ranking
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// Explanation breaks a team's composite FinalRaw into its weighted terms.
+type Explanation struct {
+	TeamID int64
+	Team   Team
+
+	RecordWeight float64
+	SRSWeight    float64
+	SOSWeight    float64
+	RecordTerm   float64
+	SRSTerm      float64
+	SOSTerm      float64
+
+	// MOVRatings holds the team's SRS at each of the sport's MOV caps.
+	MOVRatings []MOVRating
+	// Games holds the team's SRS games, most helpful first.
+	Games []GameEffect
+}
+
+// MOVRating is a team's SRS at one margin-of-victory cap, in capped points and
+// normalized to [0,1] across the division.
+type MOVRating struct {
+	Cap    int64
+	Rating float64
+	Norm   float64
+}
+
+// GameEffect is how much one game moved a team's FinalRaw through SRS: the
+// SRS term with the game minus the SRS term with the game left out, holding
+// the division's rating scale fixed.
+type GameEffect struct {
+	GameID     int64
+	Season     int64
+	Week       int64
+	Postseason int64
+	OpponentID int64
+	Opponent   string
+	Home       bool
+	Neutral    bool
+	Score      int64
+	OppScore   int64
+	Effect     float64
+}
+
+// Lookup returns the ID of the team matching query, which is either a team ID
+// or a case-insensitive team name.
+func (t TeamList) Lookup(query string) (int64, error) {
+	if id, err := strconv.ParseInt(query, 10, 64); err == nil {
+		if !t.teamExists(id) {
+			return 0, fmt.Errorf("team %d is not ranked", id)
+		}
+		return id, nil
+	}
+
+	for id, team := range t {
+		if strings.EqualFold(team.Name, query) {
+			return id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown team %q", query)
+}
+
+// Explain ranks the Ranker's division with the composite model and breaks
+// down the FinalRaw of the team matching query (see TeamList.Lookup).
+func (r *Ranker) Explain(query string) (*Explanation, error) {
+	if r.Model != "" && r.Model != DefaultModel {
+		return nil, fmt.Errorf("explanations are only available for the %s model", DefaultModel)
+	}
+
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := teamList.Lookup(query)
+	if err != nil {
+		return nil, err
+	}
+	team := teamList[id]
+
+	cfg := r.sportConfig()
+	e := &Explanation{
+		TeamID:       id,
+		Team:         *team,
+		RecordWeight: cfg.RecordWeight,
+		SRSWeight:    cfg.SRSWeight,
+		SOSWeight:    cfg.SOSWeight,
+		RecordTerm:   team.Record.Record * cfg.RecordWeight,
+		SRSTerm:      team.SRSNorm * cfg.SRSWeight,
+		SOSTerm:      team.SOSNorm * cfg.SOSWeight,
+	}
+
+	games, err := r.srsGames(teamList)
+	if err != nil {
+		return nil, err
+	}
+
+	fits := make([]map[int64]float64, len(cfg.MOVCaps))
+	for i, mov := range cfg.MOVCaps {
+		fits[i] = generateAdjRatings(games, r.srsParams(mov)).ratings
+		values := slices.Collect(maps.Values(fits[i]))
+		rating := fits[i][id]
+		var norm float64
+		if span := spread(values); span > 0 {
+			norm = (rating - slices.Min(values)) / span
+		}
+		e.MOVRatings = append(e.MOVRatings, MOVRating{Cap: mov, Rating: rating, Norm: norm})
+	}
+
+	e.Games = r.gameEffects(teamList, id, games, fits)
+
+	return e, nil
+}
+
+// gameEffects refits SRS once per game of the team's SRS game set with that
+// game left out, and returns how much each game moved the team's SRS term.
+// fits holds the full fit's ratings at each MOV cap. Changes are measured on
+// the scale of the full fit: renormalizing each refit would hide every effect
+// on the teams at the top and bottom of the scale.
+func (r *Ranker) gameEffects(
+	teamList TeamList,
+	id int64,
+	games []database.Game,
+	fits []map[int64]float64,
+) []GameEffect {
+	cfg := r.sportConfig()
+	full := make([]float64, len(cfg.MOVCaps))
+	spans := make([]float64, len(cfg.MOVCaps))
+	for i, ratings := range fits {
+		full[i] = ratings[id]
+		spans[i] = spread(slices.Collect(maps.Values(ratings)))
+	}
+	var srs []float64
+	for _, team := range teamList {
+		srs = append(srs, team.SRS)
+	}
+	srsSpan := spread(srs)
+
+	var effects []GameEffect
+	without := make([]database.Game, 0, len(games))
+	for i, game := range games {
+		if game.HomeID != id && game.AwayID != id {
+			continue
+		}
+
+		without = append(without[:0], games[:i]...)
+		without = append(without, games[i+1:]...)
+		var delta float64
+		for c, mov := range cfg.MOVCaps {
+			ratings := generateAdjRatings(without, r.srsParams(mov)).ratings
+			if spans[c] > 0 {
+				delta += (full[c] - ratings[id]) / spans[c]
+			}
+		}
+
+		effect := GameEffect{
+			GameID:     game.GameID,
+			Season:     game.Season,
+			Week:       game.Week,
+			Postseason: game.Postseason,
+			Home:       game.HomeID == id,
+			Neutral:    game.Neutral,
+		}
+		if srsSpan > 0 {
+			effect.Effect = delta / float64(len(cfg.MOVCaps)) / srsSpan * cfg.SRSWeight
+		}
+		if effect.Home {
+			effect.OpponentID, effect.Score, effect.OppScore = game.AwayID, game.HomeScore, game.AwayScore
+		} else {
+			effect.OpponentID, effect.Score, effect.OppScore = game.HomeID, game.AwayScore, game.HomeScore
+		}
+		effect.Opponent = teamList[effect.OpponentID].Name
+		effects = append(effects, effect)
+	}
+
+	slices.SortStableFunc(effects, func(a, b GameEffect) int {
+		if c := cmp.Compare(b.Effect, a.Effect); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.GameID, b.GameID)
+	})
+
+	return effects
+}
+
+// spread returns the difference between the largest and smallest values.
+func spread(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return slices.Max(values) - slices.Min(values)
+}
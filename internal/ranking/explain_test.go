@@ -0,0 +1,76 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball}
+	e, err := r.Explain("alpha")
+	if err != nil {
+		t.Fatalf("Explain: %v", err)
+	}
+
+	if e.TeamID != 1 {
+		t.Errorf("TeamID = %d, want 1", e.TeamID)
+	}
+	if got := e.RecordTerm + e.SRSTerm + e.SOSTerm; math.Abs(got-e.Team.FinalRaw) > 1e-12 {
+		t.Errorf("terms sum to %f, want FinalRaw %f", got, e.Team.FinalRaw)
+	}
+	caps := r.sportConfig().MOVCaps
+	if len(e.MOVRatings) != len(caps) {
+		t.Fatalf("MOVRatings = %+v, want caps %v", e.MOVRatings, caps)
+	}
+	// SRS is the average of the normalized ratings at each cap.
+	var norms float64
+	for i, mov := range e.MOVRatings {
+		if mov.Cap != caps[i] {
+			t.Errorf("MOVRatings[%d].Cap = %d, want %d", i, mov.Cap, caps[i])
+		}
+		norms += mov.Norm
+	}
+	if got := norms / float64(len(caps)); math.Abs(got-e.Team.SRS) > 1e-12 {
+		t.Errorf("mean cap norm = %f, want SRS %f", got, e.Team.SRS)
+	}
+	if e.MOVRatings[0].Rating != e.Team.SRSLow || e.MOVRatings[len(caps)-1].Rating != e.Team.SRSHigh {
+		t.Errorf("MOVRatings = %+v, want SRSLow %f and SRSHigh %f at the ends",
+			e.MOVRatings, e.Team.SRSLow, e.Team.SRSHigh)
+	}
+
+	// Three 2023 games against FBS teams plus the 2022 backfill game; the
+	// FCS game isn't part of SRS.
+	if len(e.Games) != 4 {
+		t.Fatalf("len(Games) = %d, want 4", len(e.Games))
+	}
+	for i, game := range e.Games {
+		if i > 0 && game.Effect > e.Games[i-1].Effect {
+			t.Errorf("games not ordered by effect: %+v", e.Games)
+		}
+		if game.OpponentID == 5 {
+			t.Errorf("FCS game %d included", game.GameID)
+		}
+	}
+	if e.Games[0].Effect <= 0 {
+		t.Errorf("most helpful game effect = %f, want > 0", e.Games[0].Effect)
+	}
+}
+
+func TestExplain_Errors(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	if _, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).Explain("Nobody"); err == nil {
+		t.Error("Explain unknown team: want error")
+	}
+	// Epsilon is FCS.
+	if _, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).Explain("5"); err == nil {
+		t.Error("Explain team outside the division: want error")
+	}
+	if _, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball, Model: "elo"}).Explain("1"); err == nil {
+		t.Error("Explain with the elo model: want error")
+	}
+}
@@ -0,0 +1,316 @@
+package ranking
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// memoryGameSource serves one sport's games and team seasons from memory. It
+// is never modified after loading, so rankers may share it. A source loaded
+// for a range of seasons reads anything outside the range from the database.
+type memoryGameSource struct {
+	sport     string
+	db        *gorm.DB
+	from, to  int64                     // loaded seasons, inclusive; zero is open
+	seasons   []int64                   // seasons with games, newest first
+	bySeason  map[int64][]database.Game // games in any status, newest first
+	yearTeams map[int64][]database.TeamSeason
+	names     map[int64]string
+
+	earlier   bool      // there are final games before from
+	nextStart time.Time // start of the first final game after to, if any
+
+	// Elo ratings going into from, with the start of the latest game they
+	// include, and the history of the loaded seasons, oldest first. Both are
+	// nil if the sport has no Elo history.
+	eloBase    map[int64]eloState
+	eloBaseEnd time.Time
+	eloHistory []database.EloHistory
+}
+
+// LoadGameSource reads the games and team seasons of sport from the seasons
+// from to to, inclusive, once, so a Ranker with it as Games ranks a week of
+// those seasons without querying the database for them. A zero from or to
+// leaves that side open. Rankings reach back ranking.YearsBack seasons, and
+// further for teams short of games; those reads go to the database.
+func LoadGameSource(db *gorm.DB, sport string, from int64, to int64) (GameSource, error) {
+	s := &memoryGameSource{
+		sport:     sport,
+		db:        db,
+		from:      from,
+		to:        to,
+		bySeason:  map[int64][]database.Game{},
+		yearTeams: map[int64][]database.TeamSeason{},
+	}
+
+	var games []database.Game
+	if err := s.inRange(db.Where("sport = ?", sport), "season").
+		Order("start_time desc").Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	var teamSeasons []database.TeamSeason
+	if err := s.inRange(db.Where("sport = ?", sport), "year").Find(&teamSeasons).Error; err != nil {
+		return nil, err
+	}
+
+	var teamNames []database.TeamName
+	if err := db.Select("team_id", "name").Where("sport = ?", sport).Find(&teamNames).Error; err != nil {
+		return nil, err
+	}
+
+	if from != 0 {
+		var prev database.Game
+		if err := db.Where("sport = ? and status = ? and season < ?", sport, database.GameFinal, from).
+			Limit(1).Find(&prev).Error; err != nil {
+			return nil, err
+		}
+		s.earlier = prev.GameID != 0
+	}
+	if to != 0 {
+		var next database.Game
+		if err := db.Where("sport = ? and status = ? and season > ?", sport, database.GameFinal, to).
+			Order("start_time asc").Limit(1).Find(&next).Error; err != nil {
+			return nil, err
+		}
+		s.nextStart = next.StartTime
+	}
+
+	if err := s.loadElo(); err != nil {
+		return nil, err
+	}
+
+	s.names = make(map[int64]string, len(teamNames))
+	for _, game := range games {
+		if _, ok := s.bySeason[game.Season]; !ok {
+			s.seasons = append(s.seasons, game.Season)
+		}
+		s.bySeason[game.Season] = append(s.bySeason[game.Season], game)
+	}
+	slices.Sort(s.seasons)
+	slices.Reverse(s.seasons)
+	for _, teamSeason := range teamSeasons {
+		s.yearTeams[teamSeason.Year] = append(s.yearTeams[teamSeason.Year], teamSeason)
+	}
+	for _, name := range teamNames {
+		s.names[name.TeamID] = name.Name
+	}
+
+	return s, nil
+}
+
+// loadElo reads the sport's Elo history: the ratings going into the loaded
+// seasons, and every rating change within them.
+func (s *memoryGameSource) loadElo() error {
+	var historyRows int64
+	if err := s.db.Model(&database.EloHistory{}).
+		Where("sport = ?", s.sport).
+		Count(&historyRows).Error; err != nil {
+		return err
+	}
+	if historyRows == 0 {
+		return nil
+	}
+
+	s.eloBase = map[int64]eloState{}
+	if s.from != 0 {
+		base, err := loadEloHistory(s.db.Where("sport = ? and season < ?", s.sport, s.from))
+		if err != nil {
+			return err
+		}
+		latestEloState(s.eloBase, base)
+		for _, row := range base {
+			if row.StartTime.After(s.eloBaseEnd) {
+				s.eloBaseEnd = row.StartTime
+			}
+		}
+	}
+
+	history, err := loadEloHistory(s.inRange(s.db.Where("sport = ?", s.sport), "season"))
+	if err != nil {
+		return err
+	}
+	s.eloHistory = history
+	return nil
+}
+
+// inRange limits tx to the loaded seasons in column.
+func (s *memoryGameSource) inRange(tx *gorm.DB, column string) *gorm.DB {
+	if s.from != 0 {
+		tx = tx.Where(column+" >= ?", s.from)
+	}
+	if s.to != 0 {
+		tx = tx.Where(column+" <= ?", s.to)
+	}
+	return tx
+}
+
+// loaded reports whether season is held in memory.
+func (s *memoryGameSource) loaded(season int64) bool {
+	return (s.from == 0 || season >= s.from) && (s.to == 0 || season <= s.to)
+}
+
+func (s *memoryGameSource) games(q gameQuery) ([]database.Game, error) {
+	if q.sport != s.sport {
+		return nil, nil
+	}
+
+	match := q.matcher()
+	var games []database.Game
+	for _, season := range s.seasons {
+		if q.maxSeason != 0 && season > q.maxSeason {
+			continue
+		}
+		if q.minSeason != 0 && season < q.minSeason {
+			break
+		}
+		if q.limit > 0 && len(games) >= q.limit {
+			break
+		}
+		for _, game := range s.bySeason[season] {
+			if match(game) {
+				games = append(games, game)
+			}
+		}
+	}
+
+	outside, err := s.unloadedGames(q)
+	if err != nil {
+		return nil, err
+	}
+	games = append(games, outside...)
+
+	// seasons can overlap at the edges, so order across them isn't given
+	slices.SortStableFunc(games, func(a, b database.Game) int {
+		return b.StartTime.Compare(a.StartTime)
+	})
+	if q.limit > 0 && len(games) > q.limit {
+		games = games[:q.limit]
+	}
+
+	return games, nil
+}
+
+// unloadedGames reads the games matching q from the seasons outside the
+// loaded range. The database is only queried when such games can match.
+func (s *memoryGameSource) unloadedGames(q gameQuery) ([]database.Game, error) {
+	stored := dbGameSource{db: s.db}
+
+	var games []database.Game
+	if s.earlier && (q.minSeason == 0 || q.minSeason < s.from) {
+		below := q
+		if below.maxSeason == 0 || below.maxSeason >= s.from {
+			below.maxSeason = s.from - 1
+		}
+		earlier, err := stored.games(below)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, earlier...)
+	}
+	if !s.nextStart.IsZero() && (q.maxSeason == 0 || q.maxSeason > s.to) &&
+		(q.before.IsZero() || !s.nextStart.After(q.before)) {
+		above := q
+		if above.minSeason <= s.to {
+			above.minSeason = s.to + 1
+		}
+		later, err := stored.games(above)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, later...)
+	}
+	return games, nil
+}
+
+func (s *memoryGameSource) weekStart(sport string, season int64, week int64) (database.Game, error) {
+	if sport != s.sport {
+		return database.Game{}, nil
+	}
+	if !s.loaded(season) {
+		return dbGameSource{db: s.db}.weekStart(sport, season, week)
+	}
+
+	games := s.bySeason[season]
+	for i := len(games) - 1; i >= 0; i-- {
+		if games[i].Week == week && games[i].Postseason == 0 {
+			return games[i], nil
+		}
+	}
+	return database.Game{}, nil
+}
+
+func (s *memoryGameSource) latestYear(sport string) (int64, error) {
+	if sport != s.sport {
+		return 0, nil
+	}
+	if s.to != 0 {
+		return dbGameSource{db: s.db}.latestYear(sport)
+	}
+
+	var year int64
+	for y := range s.yearTeams {
+		year = max(year, y)
+	}
+	return year, nil
+}
+
+func (s *memoryGameSource) divisionTeams(
+	sport string,
+	year int64,
+	division database.Division,
+) ([]divisionTeam, error) {
+	if sport != s.sport {
+		return nil, nil
+	}
+	if !s.loaded(year) {
+		return dbGameSource{db: s.db}.divisionTeams(sport, year, division)
+	}
+
+	var teams []divisionTeam
+	for _, teamSeason := range s.yearTeams[year] {
+		name, ok := s.names[teamSeason.TeamID]
+		if teamSeason.Division == division && ok {
+			teams = append(teams, divisionTeam{TeamID: teamSeason.TeamID, Name: name, Conf: teamSeason.Conf})
+		}
+	}
+	return teams, nil
+}
+
+func (s *memoryGameSource) seasonTeamIDs(sport string, year int64) ([]int64, error) {
+	if sport != s.sport {
+		return nil, nil
+	}
+	if !s.loaded(year) {
+		return dbGameSource{db: s.db}.seasonTeamIDs(sport, year)
+	}
+
+	var ids []int64
+	for _, teamSeason := range s.yearTeams[year] {
+		ids = append(ids, teamSeason.TeamID)
+	}
+	return ids, nil
+}
+
+func (s *memoryGameSource) eloRatings(sport string, before time.Time) (map[int64]eloState, error) {
+	if sport != s.sport || s.eloBase == nil {
+		return nil, nil
+	}
+	if before.Before(s.eloBaseEnd) || (!s.nextStart.IsZero() && !before.Before(s.nextStart)) {
+		// games outside the loaded seasons would count
+		return dbGameSource{db: s.db}.eloRatings(sport, before)
+	}
+
+	teams := maps.Clone(s.eloBase)
+	played := sort.Search(len(s.eloHistory), func(i int) bool {
+		return s.eloHistory[i].StartTime.After(before)
+	})
+	latestEloState(teams, s.eloHistory[:played])
+	return teams, nil
+}
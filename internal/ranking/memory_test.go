@@ -0,0 +1,211 @@
+package ranking
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+func gameIDs(games []database.Game) []int64 {
+	var ids []int64
+	for _, game := range games {
+		ids = append(ids, game.GameID)
+	}
+	return ids
+}
+
+func TestMemoryGameSource_MatchesDatabase(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+	scheduled := database.Game{
+		GameID: 1011, Season: 2023, Week: 6, HomeID: 1, AwayID: 4, Sport: sportFootball,
+		Status: database.GameScheduled, StartTime: time.Date(2023, 10, 10, 19, 0, 0, 0, time.UTC),
+	}
+	if err := db.Create(&scheduled).Error; err != nil {
+		t.Fatalf("seed scheduled game: %v", err)
+	}
+
+	// whole history, then one season each with the rest read from the
+	// database below and above it
+	for _, seasons := range [][2]int64{{0, 0}, {2023, 2023}, {2022, 2022}} {
+		memory, err := LoadGameSource(db, sportFootball, seasons[0], seasons[1])
+		if err != nil {
+			t.Fatalf("LoadGameSource(%v): %v", seasons, err)
+		}
+		testMatchesDatabase(t, db, memory, scheduled)
+	}
+}
+
+func testMatchesDatabase(t *testing.T, db *gorm.DB, memory GameSource, scheduled database.Game) {
+	t.Helper()
+	stored := dbGameSource{db: db}
+
+	queries := []gameQuery{
+		{sport: sportFootball},
+		{sport: sportBasketball},
+		{sport: sportFootball, minSeason: 2023, before: time.Date(2023, 9, 20, 0, 0, 0, 0, time.UTC)},
+		{sport: sportFootball, maxSeason: 2022, team: 1},
+		{sport: sportFootball, teams: []int64{1, 2, 3}},
+		{sport: sportFootball, team: 2, teams: []int64{1, 5}},
+		{sport: sportFootball, maxSeason: 2023, limit: 3},
+	}
+	for _, q := range queries {
+		want, err := stored.games(q)
+		if err != nil {
+			t.Fatalf("database games(%+v): %v", q, err)
+		}
+		got, err := memory.games(q)
+		if err != nil {
+			t.Fatalf("memory games(%+v): %v", q, err)
+		}
+		if !slices.Equal(gameIDs(got), gameIDs(want)) {
+			t.Errorf("games(%+v) = %v, want %v", q, gameIDs(got), gameIDs(want))
+		}
+
+		match := q.matcher()
+		matched := 0
+		for _, game := range append(want, scheduled) {
+			if match(game) {
+				matched++
+			}
+		}
+		if q.limit == 0 && matched != len(want) {
+			t.Errorf("matcher(%+v) selects %d games, want %d", q, matched, len(want))
+		}
+	}
+
+	for week := int64(1); week <= 7; week++ {
+		want, err := stored.weekStart(sportFootball, 2023, week)
+		if err != nil {
+			t.Fatalf("database weekStart(%d): %v", week, err)
+		}
+		got, err := memory.weekStart(sportFootball, 2023, week)
+		if err != nil {
+			t.Fatalf("memory weekStart(%d): %v", week, err)
+		}
+		if got.GameID != want.GameID {
+			t.Errorf("weekStart(%d) = game %d, want %d", week, got.GameID, want.GameID)
+		}
+	}
+
+	if year, err := memory.latestYear(sportFootball); err != nil || year != 2023 {
+		t.Errorf("latestYear = %d, %v, want 2023", year, err)
+	}
+	for _, division := range []database.Division{database.DivisionFBS, database.DivisionFCS} {
+		for _, year := range []int64{2022, 2023} {
+			teams, err := memory.divisionTeams(sportFootball, year, division)
+			if err != nil {
+				t.Fatalf("divisionTeams(%d, %s): %v", year, division, err)
+			}
+			want, err := stored.divisionTeams(sportFootball, year, division)
+			if err != nil {
+				t.Fatalf("database divisionTeams(%d, %s): %v", year, division, err)
+			}
+			if len(teams) != len(want) {
+				t.Errorf("divisionTeams(%d, %s) = %d teams, want %d", year, division, len(teams), len(want))
+			}
+		}
+	}
+}
+
+func TestMemoryGameSource_Ranking(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	memory, err := LoadGameSource(db, sportFootball, 0, 0)
+	if err != nil {
+		t.Fatalf("LoadGameSource: %v", err)
+	}
+	// 2022 backfills the early weeks from the database
+	season, err := LoadGameSource(db, sportFootball, 2023, 2023)
+	if err != nil {
+		t.Fatalf("LoadGameSource(2023): %v", err)
+	}
+
+	for _, division := range []database.Division{database.DivisionFBS, database.DivisionFCS} {
+		for week := int64(0); week <= 5; week++ {
+			want, err := (&Ranker{DB: db, Year: 2023, Week: week, Division: division, Sport: sportFootball}).CalculateRanking()
+			if err != nil {
+				t.Fatalf("CalculateRanking week %d: %v", week, err)
+			}
+
+			for _, games := range []GameSource{memory, season} {
+				// Without a database, any query outside the source would panic.
+				r := &Ranker{Games: games, Year: 2023, Week: week, Division: division, Sport: sportFootball}
+				got, err := r.CalculateRanking()
+				if err != nil {
+					t.Fatalf("CalculateRanking week %d from memory: %v", week, err)
+				}
+
+				if len(got) != len(want) {
+					t.Fatalf("week %d: %d teams, want %d", week, len(got), len(want))
+				}
+				for id, team := range want {
+					if got[id] == nil || *got[id] != *team {
+						t.Errorf("week %d %s team %d = %+v, want %+v", week, division, id, got[id], team)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMemoryGameSource_EloRatings(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+	if _, err := (&Ranker{DB: db, Sport: sportFootball}).UpdateEloHistory(time.Time{}); err != nil {
+		t.Fatalf("UpdateEloHistory: %v", err)
+	}
+	stored := dbGameSource{db: db}
+
+	times := []time.Time{
+		time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 9, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 9, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, seasons := range [][2]int64{{0, 0}, {2023, 2023}, {2022, 2022}} {
+		memory, err := LoadGameSource(db, sportFootball, seasons[0], seasons[1])
+		if err != nil {
+			t.Fatalf("LoadGameSource(%v): %v", seasons, err)
+		}
+		for _, before := range times {
+			want, err := stored.eloRatings(sportFootball, before)
+			if err != nil {
+				t.Fatalf("database eloRatings(%s): %v", before, err)
+			}
+			got, err := memory.eloRatings(sportFootball, before)
+			if err != nil {
+				t.Fatalf("memory eloRatings(%s): %v", before, err)
+			}
+			if !maps.Equal(got, want) {
+				t.Errorf("seasons %v: eloRatings(%s) = %v, want %v", seasons, before, got, want)
+			}
+		}
+	}
+
+	season, err := LoadGameSource(db, sportFootball, 2022, 2023)
+	if err != nil {
+		t.Fatalf("LoadGameSource: %v", err)
+	}
+	want, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball, Model: "elo"}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	// Without a database, the ratings have to come from the source.
+	got, err := (&Ranker{Games: season, Year: 2023, Sport: sportFootball, Model: "elo"}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking from memory: %v", err)
+	}
+	for id, team := range want {
+		if got[id] == nil || got[id].FinalRaw != team.FinalRaw {
+			t.Errorf("team %d = %+v, want %+v", id, got[id], team)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package ranking
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// DefaultModel is the name of the model used when a Ranker doesn't name one.
+const DefaultModel = "composite"
+
+// RatingModel rates the teams in a division. Rate is handed a team list that
+// setup has already built for the Ranker's sport, year, week and division, and
+// must fill in every team's FinalRaw and FinalRank.
+type RatingModel interface {
+	Name() string
+	Rate(r *Ranker, teamList TeamList) error
+}
+
+// models holds every selectable rating model keyed by name.
+//
+//nolint:gochecknoglobals // read-only registry
+var models = map[string]RatingModel{
+	DefaultModel: compositeModel{},
+	"colley":     colleyModel{},
+	"elo":        eloModel{},
+}
+
+// LookupModel returns the rating model registered under name. An empty name
+// selects DefaultModel.
+func LookupModel(name string) (RatingModel, error) {
+	if name == "" {
+		name = DefaultModel
+	}
+	model, ok := models[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rating model %q (available: %s)", name, strings.Join(ModelNames(), ", "))
+	}
+	return model, nil
+}
+
+// ModelNames returns the names of all registered rating models, sorted.
+func ModelNames() []string {
+	var names []string
+	for name := range models {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// compositeModel is the weighted blend of record, SRS and SOS described in
+// docs/design-decisions.md.
+type compositeModel struct{}
+
+func (compositeModel) Name() string {
+	return DefaultModel
+}
+
+func (compositeModel) Rate(r *Ranker, teamList TeamList) error {
+	if err := r.record(teamList); err != nil {
+		return err
+	}
+
+	if err := r.srs(teamList); err != nil {
+		return err
+	}
+
+	if err := r.sos(teamList); err != nil {
+		return err
+	}
+
+	if err := r.strength(teamList); err != nil {
+		return err
+	}
+
+	r.finalRanking(teamList)
+
+	if r.Resamples > 0 {
+		return r.uncertainty(teamList)
+	}
+
+	return nil
+}
+
+// rankDivisions ranks a unified team list within each division: the rank
+// across both becomes OverallRank and FinalRank is recomputed per division.
+func rankDivisions(teamList TeamList) {
+	divisions := map[database.Division]TeamList{}
+	for id, team := range teamList {
+		team.OverallRank = team.FinalRank
+		if divisions[team.Division] == nil {
+			divisions[team.Division] = TeamList{}
+		}
+		divisions[team.Division][id] = team
+	}
+	for _, division := range divisions {
+		rankFinal(division)
+	}
+}
+
+// rankFinal sets FinalRank from FinalRaw, highest first. Tied teams share a
+// rank.
+func rankFinal(teamList TeamList) {
+	var ids []int64
+	for id := range teamList {
+		ids = append(ids, id)
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return teamList[ids[i]].FinalRaw > teamList[ids[j]].FinalRaw
+	})
+
+	var prev float64
+	var prevRank int64
+	for rank, id := range ids {
+		team := teamList[id]
+		if team.FinalRaw == prev {
+			team.FinalRank = prevRank
+		} else {
+			team.FinalRank = int64(rank + 1)
+			prev = team.FinalRaw
+			prevRank = team.FinalRank
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package ranking
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookupModel_Default(t *testing.T) {
+	model, err := LookupModel("")
+	if err != nil {
+		t.Fatalf("LookupModel: %v", err)
+	}
+	if model.Name() != DefaultModel {
+		t.Errorf("model = %q, want %q", model.Name(), DefaultModel)
+	}
+}
+
+func TestLookupModel_Unknown(t *testing.T) {
+	if _, err := LookupModel("bogus"); err == nil {
+		t.Error("LookupModel(bogus) returned no error")
+	}
+}
+
+func TestModelNames(t *testing.T) {
+	names := ModelNames()
+	if !slices.Contains(names, DefaultModel) {
+		t.Errorf("ModelNames() = %v, missing %q", names, DefaultModel)
+	}
+	if !slices.IsSorted(names) {
+		t.Errorf("ModelNames() = %v, want sorted", names)
+	}
+	for _, name := range names {
+		model, err := LookupModel(name)
+		if err != nil {
+			t.Fatalf("LookupModel(%q): %v", name, err)
+		}
+		if model.Name() != name {
+			t.Errorf("model registered as %q reports name %q", name, model.Name())
+		}
+	}
+}
+
+func TestCalculateRanking_UnknownModel(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball, Model: "bogus"}
+	if _, err := r.CalculateRanking(); err == nil {
+		t.Error("CalculateRanking with unknown model returned no error")
+	}
+}
@@ -0,0 +1,74 @@
+package ranking
+
+import (
+	"math"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// PointRatings are SRS ratings on a points-per-game scale, as opposed to the
+// normalized SRS used by the composite ranking. The expected margin of a game
+// is the home rating minus the away rating, plus HomeAdvantage at a
+// non-neutral site.
+type PointRatings struct {
+	Year          int64
+	Week          int64
+	Ratings       map[int64]float64
+	Names         map[int64]string
+	HomeAdvantage float64 // points, for the ranking season
+	Sigma         float64 // standard deviation of actual margins around the expected margin
+}
+
+// PointRatings fits SRS for the Ranker's division using games before the
+// ranking week. Margins are capped at the sport's largest MOV cap, the same
+// fit that feeds the composite ranking.
+func (r *Ranker) PointRatings() (PointRatings, error) {
+	teamList, err := r.setup()
+	if err != nil {
+		return PointRatings{}, err
+	}
+
+	games, err := r.srsGames(teamList)
+	if err != nil {
+		return PointRatings{}, err
+	}
+
+	names := map[int64]string{}
+	for id, team := range teamList {
+		names[id] = team.Name
+	}
+
+	if len(games) == 0 {
+		// nothing to fit; every team is unrated
+		return PointRatings{Year: r.Year, Week: r.Week, Ratings: map[int64]float64{}, Names: names}, nil
+	}
+
+	cfg := r.sportConfig()
+	mov := cfg.MOVCaps[len(cfg.MOVCaps)-1]
+	result := generateAdjRatings(games, r.srsParams(mov))
+
+	return PointRatings{
+		Year:          r.Year,
+		Week:          r.Week,
+		Ratings:       result.ratings,
+		Names:         names,
+		HomeAdvantage: result.homeAdv.forGame(database.Game{Season: r.Year}),
+		Sigma:         marginSigma(games, result),
+	}, nil
+}
+
+// marginSigma returns the root-mean-square difference between each game's
+// uncapped margin and the margin the ratings expect.
+func marginSigma(games []database.Game, result srsResult) float64 {
+	if len(games) == 0 {
+		return 0
+	}
+
+	var sumSq float64
+	for _, game := range games {
+		expected := result.ratings[game.HomeID] - result.ratings[game.AwayID] + result.homeAdv.forGame(game)
+		residual := float64(game.HomeScore-game.AwayScore) - expected
+		sumSq += residual * residual
+	}
+	return math.Sqrt(sumSq / float64(len(games)))
+}
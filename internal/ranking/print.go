@@ -5,17 +5,25 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 )
 
 func (r *Ranker) PrintRankings(teamList TeamList, top int) {
+	// a unified ranking is listed across divisions
+	rank := func(team *Team) int64 { return team.FinalRank }
+	if r.unified() {
+		rank = func(team *Team) int64 { return team.OverallRank }
+	}
+
 	var ids []int64
 	for id := range teamList {
 		ids = append(ids, id)
 	}
 	sort.SliceStable(ids, func(i, j int) bool {
-		return teamList[ids[i]].FinalRank < teamList[ids[j]].FinalRank
+		return rank(teamList[ids[i]]) < rank(teamList[ids[j]])
 	})
 
 	if r.postseason {
@@ -28,15 +36,32 @@ func (r *Ranker) PrintRankings(teamList TeamList, top int) {
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
 	t.SetStyle(table.StyleRounded)
-	t.AppendHeader(table.Row{
-		"Rank", "Team", "Conf", "Record", "SRS", "SoS", "Total",
-	})
+	header := table.Row{"Rank"}
+	if r.unified() {
+		header = append(header, "Div")
+	}
+	header = append(header, "Team", "Conf", "Record", "SRS", "SoS", "Total")
+	if r.Resamples > 0 {
+		header = append(header, "Range", "Interval")
+	}
+	t.AppendHeader(header)
 	for i := 0; i < top; i++ {
 		team := teamList[ids[i]]
-		t.AppendRow(table.Row{
-			team.FinalRank, team.Name, team.Conf, team.Record, team.SRSRank,
+		row := table.Row{rank(team)}
+		if r.unified() {
+			row = append(row, fmt.Sprintf("%s %d", strings.ToUpper(string(team.Division)), team.FinalRank))
+		}
+		row = append(row,
+			team.Name, team.Conf, team.Record, team.SRSRank,
 			team.SOSRank, fmt.Sprintf("%.5f", team.FinalRaw),
-		})
+		)
+		if r.Resamples > 0 {
+			row = append(row,
+				fmt.Sprintf("%d-%d", team.RankBest, team.RankWorst),
+				fmt.Sprintf("%.5f-%.5f", team.FinalRawLow, team.FinalRawHigh),
+			)
+		}
+		t.AppendRow(row)
 	}
 	t.Render()
 }
@@ -69,3 +94,142 @@ func (r *Ranker) PrintSRS(teamList TeamList, top int) {
 	}
 	t.Render()
 }
+
+// PrintExplanation writes a team's FinalRaw broken into its weighted terms,
+// followed by its games from most to least helpful.
+func (r *Ranker) PrintExplanation(e *Explanation) {
+	if r.postseason {
+		fmt.Printf("%d Final\n", r.Year)
+	} else {
+		fmt.Printf("%d Week %d\n", r.Year, r.Week)
+	}
+	fmt.Printf("#%d %s (%s) %s\n", e.Team.FinalRank, e.Team.Name, e.Team.Conf, e.Team.Record)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Term", "Value", "Norm", "Weight", "Points"})
+	t.AppendRow(table.Row{
+		"Record", fmt.Sprintf("%.5f", e.Team.Record.Record), "",
+		fmt.Sprintf("%.2f", e.RecordWeight), fmt.Sprintf("%.5f", e.RecordTerm),
+	})
+	for _, mov := range e.MOVRatings {
+		t.AppendRow(table.Row{
+			fmt.Sprintf("  SRS, MOV cap %d", mov.Cap), fmt.Sprintf("%.2f", mov.Rating),
+			fmt.Sprintf("%.5f", mov.Norm), "", "",
+		})
+	}
+	t.AppendRow(table.Row{
+		fmt.Sprintf("SRS (#%d)", e.Team.SRSRank), fmt.Sprintf("%.5f", e.Team.SRS),
+		fmt.Sprintf("%.5f", e.Team.SRSNorm), fmt.Sprintf("%.2f", e.SRSWeight), fmt.Sprintf("%.5f", e.SRSTerm),
+	})
+	t.AppendRow(table.Row{
+		fmt.Sprintf("SoS (#%d)", e.Team.SOSRank), fmt.Sprintf("%.5f", e.Team.SOS),
+		fmt.Sprintf("%.5f", e.Team.SOSNorm), fmt.Sprintf("%.2f", e.SOSWeight), fmt.Sprintf("%.5f", e.SOSTerm),
+	})
+	t.AppendFooter(table.Row{"Total", "", "", "", fmt.Sprintf("%.5f", e.Team.FinalRaw)})
+	t.Render()
+
+	g := table.NewWriter()
+	g.SetOutputMirror(os.Stdout)
+	g.SetStyle(table.StyleRounded)
+	g.AppendHeader(table.Row{"Season", "Week", "Opponent", "Site", "Result", "Effect"})
+	for _, game := range e.Games {
+		site := "Away"
+		switch {
+		case game.Neutral:
+			site = "Neutral"
+		case game.Home:
+			site = "Home"
+		}
+
+		result := "T"
+		switch {
+		case game.Score > game.OppScore:
+			result = "W"
+		case game.Score < game.OppScore:
+			result = "L"
+		}
+
+		week := strconv.FormatInt(game.Week, 10)
+		if game.Postseason > 0 {
+			week = "Post"
+		}
+
+		g.AppendRow(table.Row{
+			game.Season, week, game.Opponent, site,
+			fmt.Sprintf("%s %d-%d", result, game.Score, game.OppScore), fmt.Sprintf("%+.5f", game.Effect),
+		})
+	}
+	g.Render()
+}
+
+// PrintWhatIf writes the ranking after hypothetical games alongside each
+// team's rank and score before them.
+func (r *Ranker) PrintWhatIf(before TeamList, after TeamList, top int) {
+	var ids []int64
+	for id := range after {
+		ids = append(ids, id)
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return after[ids[i]].FinalRank < after[ids[j]].FinalRank
+	})
+
+	if r.postseason {
+		fmt.Printf("%d Final\n", r.Year)
+	} else {
+		fmt.Printf("%d Week %d\n", r.Year, r.Week)
+	}
+	fmt.Printf("Games up to %v, with %d hypothetical\n", r.startTime, len(r.Hypothetical))
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{
+		"Rank", "Was", "Move", "Team", "Conf", "Record", "SRS", "SoS", "Total", "Change",
+	})
+	for i := 0; i < top; i++ {
+		team := after[ids[i]]
+		var was int64
+		var move, change string
+		if prev, ok := before[ids[i]]; ok {
+			was = prev.FinalRank
+			if diff := prev.FinalRank - team.FinalRank; diff != 0 {
+				move = fmt.Sprintf("%+d", diff)
+			}
+			change = fmt.Sprintf("%+.5f", team.FinalRaw-prev.FinalRaw)
+		}
+		t.AppendRow(table.Row{
+			team.FinalRank, was, move, team.Name, team.Conf, team.Record, team.SRSRank,
+			team.SOSRank, fmt.Sprintf("%.5f", team.FinalRaw), change,
+		})
+	}
+	t.Render()
+}
+
+// PrintConferences writes conference strengths as a table.
+func (r *Ranker) PrintConferences(conferences []ConferenceStrength) {
+	if r.postseason {
+		fmt.Printf("%d Final\n", r.Year)
+	} else {
+		fmt.Printf("%d Week %d\n", r.Year, r.Week)
+	}
+	fmt.Printf("Games up to %v\n", r.startTime)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{
+		"Rank", "Conf", "Teams", "Mean", "Median", "Depth", "Best", "Worst", "Non-Conf",
+	})
+	for _, conf := range conferences {
+		t.AppendRow(table.Row{
+			conf.Rank, conf.Conf, conf.Teams,
+			fmt.Sprintf("%.5f", conf.MeanRaw),
+			fmt.Sprintf("%.5f", conf.MedianRaw),
+			fmt.Sprintf("%.5f", conf.Depth),
+			conf.BestRank, conf.WorstRank, conf.NonConf,
+		})
+	}
+	t.Render()
+}
@@ -2,10 +2,11 @@ package ranking
 
 import (
 	"fmt"
-	"sort"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
 )
 
 const (
@@ -14,23 +15,42 @@ const (
 )
 
 type Ranker struct {
-	DB    *gorm.DB
-	Year  int64
-	Week  int64
-	Fcs   bool
-	Sport string // sportFootball or sportBasketball
+	DB   *gorm.DB
+	Year int64
+	Week int64
+	// Division is the football division to rank; empty ranks FBS. Basketball
+	// ranks Division I whatever its value.
+	Division database.Division
+	// Unified rates FBS and FCS together on one scale, linked by the games
+	// between them. FinalRank stays the rank within each team's division and
+	// OverallRank is the rank across both. Football only; overrides Division.
+	Unified bool
+	Sport   string // sportFootball or sportBasketball
+	Model   string // registered RatingModel name; empty selects DefaultModel
+	// Resamples is the number of bootstrap resamples used to estimate each
+	// team's FinalRaw interval and rank range; 0 skips them. Composite only.
+	Resamples int
+	// Hypothetical results are ranked as if final without being stored.
+	Hypothetical []HypotheticalGame
+	// Games supplies games and team seasons; nil reads them from DB. Use
+	// LoadGameSource when ranking many weeks.
+	Games GameSource
 
 	startTime  time.Time
 	postseason bool
+	source     GameSource // Games overlaid with Hypothetical
 }
 
 type sportParams struct {
 	RequiredGames int
 	YearsBack     int64
 	MOVCaps       []int64
+	HomeField     homeFieldMode
+	HalfLifeDays  float64 // days before the cutoff per halving of a game's SRS weight; 0 weighs all equally
 	RecordWeight  float64
 	SRSWeight     float64
 	SOSWeight     float64
+	Elo           eloParams
 }
 
 // sportConfig returns ranking constants appropriate for the sport.
@@ -39,18 +59,47 @@ func (r *Ranker) sportConfig() sportParams {
 	case sportBasketball:
 		return sportParams{
 			RequiredGames: 25, YearsBack: 1, MOVCaps: []int64{1, 20},
+			HomeField: homeFieldBySeason, HalfLifeDays: 240,
 			RecordWeight: 0.25, SRSWeight: 0.60, SOSWeight: 0.15,
+			Elo: eloParams{Initial: 1500, K: 20, MOVFactor: 2.2, HomeAdvantage: 80, Regression: 0.25},
 		}
 	case sportFootball:
 		return sportParams{
 			RequiredGames: 12, YearsBack: 2, MOVCaps: []int64{1, 30},
+			HomeField: homeFieldBySeason, HalfLifeDays: 365,
 			RecordWeight: 0.45, SRSWeight: 0.40, SOSWeight: 0.15,
+			Elo: eloParams{Initial: 1500, K: 25, MOVFactor: 2.2, HomeAdvantage: 55, Regression: 0.4},
 		}
 	default:
 		panic(fmt.Sprintf("unknown sport: %q", r.Sport))
 	}
 }
 
+// YearsBack returns how many seasons before the ranking season a sport's SRS
+// backfills from, and so how many later seasons a game's result can reach.
+func YearsBack(sport string) int64 {
+	return (&Ranker{Sport: sport}).sportConfig().YearsBack
+}
+
+// finalGames starts a games query limited to final games. Scheduled,
+// in-progress, postponed and canceled games never count toward a ranking.
+func (r *Ranker) finalGames() *gorm.DB {
+	return r.DB.Where("status = ?", database.GameFinal)
+}
+
+// unified reports whether the Ranker rates FBS and FCS together.
+func (r *Ranker) unified() bool {
+	return r.Unified && r.Sport != sportBasketball
+}
+
+// division returns the division the Ranker ranks when it isn't unified.
+func (r *Ranker) division() database.Division {
+	if r.Division == "" || r.Sport == sportBasketball {
+		return database.DivisionFBS
+	}
+	return r.Division
+}
+
 func (r *Ranker) sportFilter() string {
 	switch r.Sport {
 	case sportFootball, sportBasketball:
@@ -63,6 +112,7 @@ func (r *Ranker) sportFilter() string {
 type Team struct {
 	Name          string
 	Conf          string
+	Division      database.Division
 	Year          int64
 	Week          int64
 	Postseason    int64
@@ -88,6 +138,11 @@ type Team struct {
 	SOLRank       int64
 	FinalRaw      float64
 	FinalRank     int64
+	OverallRank   int64 // rank across divisions in a unified ranking; zero otherwise
+	FinalRawLow   float64
+	FinalRawHigh  float64
+	RankBest      int64
+	RankWorst     int64
 }
 
 type Record struct {
@@ -112,29 +167,44 @@ func (t TeamList) teamExists(team int64) bool {
 }
 
 func (r *Ranker) CalculateRanking() (TeamList, error) {
-	var teamList TeamList
-	teamList, err := r.setup()
+	model, err := LookupModel(r.Model)
 	if err != nil {
 		return nil, err
 	}
-
-	if err = r.record(teamList); err != nil {
-		return nil, err
+	if r.Resamples > 0 && model.Name() != DefaultModel {
+		return nil, fmt.Errorf("uncertainty is only available for the %s model", DefaultModel)
 	}
 
-	if err = r.srs(teamList); err != nil {
+	var teamList TeamList
+	teamList, err = r.setup()
+	if err != nil {
 		return nil, err
 	}
+	if len(teamList) == 0 {
+		// A division without teams, e.g. one not ingested for the season.
+		return teamList, nil
+	}
 
-	if err = r.sos(teamList); err != nil {
+	if err = model.Rate(r, teamList); err != nil {
 		return nil, err
 	}
-
-	r.finalRanking(teamList)
+	if r.unified() {
+		rankDivisions(teamList)
+	}
 
 	return teamList, nil
 }
 
+// ProjectFinalRaw returns the composite score team would have with a different
+// record, holding its SRS and SOS fixed. It approximates the ranking at the
+// end of a season without refitting the ratings for every possible outcome.
+func (r *Ranker) ProjectFinalRaw(team *Team, wins int64, losses int64, ties int64) float64 {
+	cfg := r.sportConfig()
+	return (recordScore(wins, losses, ties) * cfg.RecordWeight) +
+		(team.SRSNorm * cfg.SRSWeight) +
+		(team.SOSNorm * cfg.SOSWeight)
+}
+
 func (r *Ranker) finalRanking(teamList TeamList) {
 	cfg := r.sportConfig()
 	for _, team := range teamList {
@@ -143,24 +213,5 @@ func (r *Ranker) finalRanking(teamList TeamList) {
 			(team.SOSNorm * cfg.SOSWeight)
 	}
 
-	var ids []int64
-	for id := range teamList {
-		ids = append(ids, id)
-	}
-	sort.SliceStable(ids, func(i, j int) bool {
-		return teamList[ids[i]].FinalRaw > teamList[ids[j]].FinalRaw
-	})
-
-	var prev float64
-	var prevRank int64
-	for rank, id := range ids {
-		team := teamList[id]
-		if team.FinalRaw == prev {
-			team.FinalRank = prevRank
-		} else {
-			team.FinalRank = int64(rank + 1)
-			prev = team.FinalRaw
-			prevRank = team.FinalRank
-		}
-	}
+	rankFinal(teamList)
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/robby-barton/stats-go/internal/database"
 )
 
-// seedBasketballData inserts 5 basketball teams (all FBS=1) and 6 games across
+// seedBasketballData inserts 5 basketball teams (all FBS) and 6 games across
 // 2 weeks for the 2024 season. Also inserts one football game to confirm
 // sport filtering excludes it.
 func seedBasketballData(t *testing.T, db *gorm.DB) {
@@ -28,11 +28,11 @@ func seedBasketballData(t *testing.T, db *gorm.DB) {
 	}
 
 	teamSeasons := []database.TeamSeason{
-		{TeamID: 101, Year: 2024, FBS: 1, Conf: "Big East", Sport: "ncaam"},
-		{TeamID: 102, Year: 2024, FBS: 1, Conf: "Big East", Sport: "ncaam"},
-		{TeamID: 103, Year: 2024, FBS: 1, Conf: "ACC", Sport: "ncaam"},
-		{TeamID: 104, Year: 2024, FBS: 1, Conf: "ACC", Sport: "ncaam"},
-		{TeamID: 105, Year: 2024, FBS: 1, Conf: "Big 12", Sport: "ncaam"},
+		{TeamID: 101, Year: 2024, Division: database.DivisionFBS, Conf: "Big East", Sport: "ncaam"},
+		{TeamID: 102, Year: 2024, Division: database.DivisionFBS, Conf: "Big East", Sport: "ncaam"},
+		{TeamID: 103, Year: 2024, Division: database.DivisionFBS, Conf: "ACC", Sport: "ncaam"},
+		{TeamID: 104, Year: 2024, Division: database.DivisionFBS, Conf: "ACC", Sport: "ncaam"},
+		{TeamID: 105, Year: 2024, Division: database.DivisionFBS, Conf: "Big 12", Sport: "ncaam"},
 	}
 	if err := db.Create(&teamSeasons).Error; err != nil {
 		t.Fatalf("seed basketball team_seasons: %v", err)
@@ -99,19 +99,19 @@ func TestCreateTeamList_Basketball(t *testing.T) {
 
 	r := &Ranker{DB: db, Year: 2024, Week: 3, Sport: "ncaam"}
 
-	// All basketball teams have FBS=1
-	teamList, err := r.createTeamList(1)
+	// All basketball teams are FBS, the top division
+	teamList, err := r.createTeamList(database.DivisionFBS)
 	if err != nil {
-		t.Fatalf("createTeamList(1): %v", err)
+		t.Fatalf("createTeamList(fbs): %v", err)
 	}
 	if len(teamList) != 5 {
 		t.Fatalf("len(teamList) = %d, want 5", len(teamList))
 	}
 
-	// No FCS in basketball — createTeamList(0) should return empty
-	teamListFCS, err := r.createTeamList(0)
+	// No FCS in basketball — the FCS team list should return empty
+	teamListFCS, err := r.createTeamList(database.DivisionFCS)
 	if err != nil {
-		t.Fatalf("createTeamList(0): %v", err)
+		t.Fatalf("createTeamList(fcs): %v", err)
 	}
 	if len(teamListFCS) != 0 {
 		t.Errorf("len(teamList FCS) = %d, want 0", len(teamListFCS))
@@ -207,7 +207,7 @@ func TestCalculateRanking_Basketball(t *testing.T) {
 		t.Fatalf("CalculateRanking: %v", err)
 	}
 
-	// All 5 basketball teams should be included (all FBS=1 for ncaam)
+	// All 5 basketball teams should be included (all FBS for ncaam)
 	if len(teamList) != 5 {
 		t.Fatalf("len(teamList) = %d, want 5", len(teamList))
 	}
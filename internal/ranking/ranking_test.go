@@ -3,6 +3,7 @@ package ranking
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/robby-barton/stats-go/internal/database"
 )
@@ -145,7 +146,7 @@ func TestGenerateAdjRatings_SmallSet(t *testing.T) {
 		{HomeID: 1, AwayID: 3, HomeScore: 35, AwayScore: 10},
 	}
 
-	ratings := generateAdjRatings(games, 30)
+	ratings := generateAdjRatings(games, srsParams{mov: 30}).ratings
 
 	// Team 1 should have the highest rating (won all games)
 	// Team 3 should have the lowest rating (lost all games)
@@ -164,9 +165,9 @@ func TestGenerateAdjRatings_MOVCapping(t *testing.T) {
 	}
 
 	// With MOV=1, spread should be capped to 1
-	ratingsMov1 := generateAdjRatings(games, 1)
+	ratingsMov1 := generateAdjRatings(games, srsParams{mov: 1}).ratings
 	// With MOV=30, spread should be capped to 30
-	ratingsMov30 := generateAdjRatings(games, 30)
+	ratingsMov30 := generateAdjRatings(games, srsParams{mov: 30}).ratings
 
 	// With MOV=1, the initial average spread for team 1 should be 1.0
 	if math.Abs(ratingsMov1[1]-1.0) > 0.01 {
@@ -192,7 +193,7 @@ func TestGenerateAdjRatings_MOVCapping(t *testing.T) {
 }
 
 func TestGenerateAdjRatings_EmptyGames(t *testing.T) {
-	ratings := generateAdjRatings(nil, 30)
+	ratings := generateAdjRatings(nil, srsParams{mov: 30}).ratings
 
 	if len(ratings) != 0 {
 		t.Errorf("expected empty ratings for no games, got %d entries", len(ratings))
@@ -205,7 +206,7 @@ func TestGenerateAdjRatings_TeamZeroRemoved(t *testing.T) {
 		{HomeID: 0, AwayID: 1, HomeScore: 10, AwayScore: 20},
 	}
 
-	ratings := generateAdjRatings(games, 30)
+	ratings := generateAdjRatings(games, srsParams{mov: 30}).ratings
 
 	if _, ok := ratings[0]; ok {
 		t.Error("team ID 0 should be removed from ratings")
@@ -214,3 +215,221 @@ func TestGenerateAdjRatings_TeamZeroRemoved(t *testing.T) {
 		t.Error("team ID 1 should exist in ratings")
 	}
 }
+
+func TestGenerateAdjRatings_HomeField(t *testing.T) {
+	// Eight evenly matched teams play a home-and-home round robin in two
+	// seasons. Home teams win every game by 3 in 2022 and by 5 in 2023, so the
+	// ratings should be level and the advantage should absorb every margin.
+	var games []database.Game
+	for season, margin := range map[int64]int64{2022: 3, 2023: 5} {
+		for home := int64(1); home <= 8; home++ {
+			for away := int64(1); away <= 8; away++ {
+				if home == away {
+					continue
+				}
+				games = append(games, database.Game{
+					Season: season, HomeID: home, AwayID: away, HomeScore: 20 + margin, AwayScore: 20,
+				})
+			}
+		}
+	}
+
+	res := generateAdjRatings(games, srsParams{mov: 30, homeField: homeFieldBySeason})
+
+	if math.Abs(res.homeAdv.pooled-4) > 0.001 {
+		t.Errorf("pooled home advantage = %f, want 4", res.homeAdv.pooled)
+	}
+	if math.Abs(res.homeAdv.bySeason[2022]-3) > 0.001 {
+		t.Errorf("2022 home advantage = %f, want 3", res.homeAdv.bySeason[2022])
+	}
+	if math.Abs(res.homeAdv.bySeason[2023]-5) > 0.001 {
+		t.Errorf("2023 home advantage = %f, want 5", res.homeAdv.bySeason[2023])
+	}
+	for id, rating := range res.ratings {
+		if math.Abs(rating-res.ratings[1]) > 0.001 {
+			t.Errorf("team %d rating = %f, want %f", id, rating, res.ratings[1])
+		}
+	}
+
+	if hfa := res.homeAdv.forGame(database.Game{Season: 2023, Neutral: true}); hfa != 0 {
+		t.Errorf("neutral-site advantage = %f, want 0", hfa)
+	}
+}
+
+func TestGenerateAdjRatings_HomeFieldTooFewGames(t *testing.T) {
+	games := []database.Game{
+		{HomeID: 1, AwayID: 2, HomeScore: 28, AwayScore: 14},
+		{HomeID: 2, AwayID: 3, HomeScore: 21, AwayScore: 7},
+	}
+
+	res := generateAdjRatings(games, srsParams{mov: 30, homeField: homeFieldPooled})
+
+	if res.homeAdv.pooled != 0 || len(res.homeAdv.bySeason) != 0 {
+		t.Errorf("home advantage = %+v, want none below %d games", res.homeAdv, minHomeFieldGames)
+	}
+}
+
+func TestGenerateAdjRatings_FixedPoint(t *testing.T) {
+	games := []database.Game{
+		{HomeID: 1, AwayID: 2, HomeScore: 28, AwayScore: 14},
+		{HomeID: 2, AwayID: 3, HomeScore: 21, AwayScore: 7},
+		{HomeID: 1, AwayID: 3, HomeScore: 35, AwayScore: 10},
+		{HomeID: 3, AwayID: 4, HomeScore: 17, AwayScore: 13},
+		{HomeID: 4, AwayID: 1, HomeScore: 3, AwayScore: 24},
+	}
+
+	ratings := generateAdjRatings(games, srsParams{mov: 30}).ratings
+
+	// Every rating should equal its average spread plus the average rating of
+	// its opponents, and the ratings should sum to zero.
+	spreads := map[int64][]float64{}
+	opponents := map[int64][]int64{}
+	for _, game := range games {
+		spread := float64(game.HomeScore - game.AwayScore)
+		spreads[game.HomeID] = append(spreads[game.HomeID], spread)
+		spreads[game.AwayID] = append(spreads[game.AwayID], -spread)
+		opponents[game.HomeID] = append(opponents[game.HomeID], game.AwayID)
+		opponents[game.AwayID] = append(opponents[game.AwayID], game.HomeID)
+	}
+	var sum float64
+	for id, rating := range ratings {
+		var want float64
+		for i, spread := range spreads[id] {
+			want += spread + ratings[opponents[id][i]]
+		}
+		want /= float64(len(spreads[id]))
+		if math.Abs(rating-want) > 1e-6 {
+			t.Errorf("team %d rating = %f, want %f", id, rating, want)
+		}
+		sum += rating
+	}
+	if math.Abs(sum) > 1e-6 {
+		t.Errorf("sum of ratings = %f, want 0", sum)
+	}
+}
+
+func TestGenerateAdjRatings_Disconnected(t *testing.T) {
+	// Two pairs of teams that never meet can't be put on one scale, so the
+	// iterative fallback rates each pair on its own.
+	games := []database.Game{
+		{HomeID: 1, AwayID: 2, HomeScore: 28, AwayScore: 14},
+		{HomeID: 3, AwayID: 4, HomeScore: 10, AwayScore: 17},
+	}
+
+	ratings := generateAdjRatings(games, srsParams{mov: 30}).ratings
+
+	if diff := ratings[1] - ratings[2]; math.Abs(diff-14) > 1e-6 {
+		t.Errorf("team 1 - team 2 = %f, want 14", diff)
+	}
+	if diff := ratings[4] - ratings[3]; math.Abs(diff-7) > 1e-6 {
+		t.Errorf("team 4 - team 3 = %f, want 7", diff)
+	}
+}
+
+func TestRecencyWeight(t *testing.T) {
+	asOf := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		start    time.Time
+		halfLife float64
+		want     float64
+	}{
+		{asOf, 365, 1},
+		{asOf.AddDate(0, 0, -365), 365, 0.5},
+		{asOf.AddDate(0, 0, -730), 365, 0.25},
+		{asOf.AddDate(0, 0, 7), 365, 1}, // after the cutoff, e.g. a hypothetical game
+		{asOf.AddDate(-5, 0, 0), 0, 1},  // no half-life
+	}
+	for _, tt := range tests {
+		if got := recencyWeight(database.Game{StartTime: tt.start}, asOf, tt.halfLife); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("recencyWeight(%s, half-life %v) = %f, want %f", tt.start, tt.halfLife, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateAdjRatings_RecencyWeight(t *testing.T) {
+	// Team 1 won by 10 a year ago and lost by 10 today. With a one-year
+	// half-life the old win counts half as much as the recent loss.
+	asOf := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{HomeID: 1, AwayID: 2, HomeScore: 20, AwayScore: 10, StartTime: asOf.AddDate(0, 0, -365)},
+		{HomeID: 1, AwayID: 2, HomeScore: 10, AwayScore: 20, StartTime: asOf},
+	}
+
+	ratings := generateAdjRatings(games, srsParams{mov: 30}).ratings
+	if diff := ratings[1] - ratings[2]; math.Abs(diff) > 1e-6 {
+		t.Errorf("unweighted team 1 - team 2 = %f, want 0", diff)
+	}
+
+	ratings = generateAdjRatings(games, srsParams{mov: 30, asOf: asOf, halfLifeDays: 365}).ratings
+	if diff := ratings[1] - ratings[2]; math.Abs(diff+10.0/3) > 1e-6 {
+		t.Errorf("weighted team 1 - team 2 = %f, want %f", diff, -10.0/3)
+	}
+}
+
+func TestPointRatings(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Week: 3, Sport: sportFootball}
+	ratings, err := r.PointRatings()
+	if err != nil {
+		t.Fatalf("PointRatings: %v", err)
+	}
+
+	if ratings.Year != 2023 || ratings.Week != 3 {
+		t.Errorf("year/week = %d/%d, want 2023/3", ratings.Year, ratings.Week)
+	}
+	if len(ratings.Ratings) != 4 {
+		t.Fatalf("len(Ratings) = %d, want 4", len(ratings.Ratings))
+	}
+	// Too few games to estimate a home edge.
+	if ratings.HomeAdvantage != 0 {
+		t.Errorf("HomeAdvantage = %f, want 0", ratings.HomeAdvantage)
+	}
+	if ratings.Sigma <= 0 {
+		t.Errorf("Sigma = %f, want > 0", ratings.Sigma)
+	}
+	// Alpha has beaten everyone it played before week 3.
+	for id, rating := range ratings.Ratings {
+		if id != 1 && rating >= ratings.Ratings[1] {
+			t.Errorf("team %d rating %f >= Alpha %f", id, rating, ratings.Ratings[1])
+		}
+	}
+}
+
+func TestCalculateRanking_IgnoresUnfinishedGames(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	// Delta "beating" Alpha in a game that hasn't finished must not count.
+	unfinished := []database.Game{
+		{
+			GameID: 1011, Season: 2023, Week: 6, HomeID: 4, AwayID: 1, HomeScore: 21, AwayScore: 0,
+			Sport: "ncaaf", Status: database.GameInProgress,
+			StartTime: time.Date(2023, 10, 7, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			GameID: 1012, Season: 2023, Week: 7, HomeID: 1, AwayID: 2,
+			Sport: "ncaaf", Status: database.GameScheduled,
+			StartTime: time.Date(2023, 10, 14, 19, 0, 0, 0, time.UTC),
+		},
+	}
+	if err := db.Create(&unfinished).Error; err != nil {
+		t.Fatalf("seed games: %v", err)
+	}
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball}
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	// The ranking is for the week after the last final game.
+	if r.Week != 6 {
+		t.Errorf("Week = %d, want 6", r.Week)
+	}
+	if teamList[1].Record.Losses != 0 || teamList[4].Record.Wins != 0 {
+		t.Errorf("Alpha losses = %d, Delta wins = %d, want 0 and 0",
+			teamList[1].Record.Losses, teamList[4].Record.Wins)
+	}
+}
@@ -1,18 +1,26 @@
 package ranking
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"math"
-	"reflect"
+	"slices"
 	"sort"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 
 	"github.com/robby-barton/stats-go/internal/database"
 )
 
-const runs int = 10000
+const (
+	// runs caps the iterative SRS fallback and the home-field refinement.
+	runs int = 10000
+	// srsTolerance is the largest change, in points, in any rating or
+	// home-field estimate at which SRS is considered converged.
+	srsTolerance = 1e-9
+)
 
 type gameResults struct {
 	team     int64
@@ -22,28 +30,50 @@ type gameResults struct {
 }
 
 func (r *Ranker) sos(teamList TeamList) error {
+	gameList, err := r.sosGames(teamList)
+	if err != nil {
+		return err
+	}
+
+	return rateSOS(teamList, gameList)
+}
+
+// sosGames returns the games SOS is solved from: this season's games between
+// division-mates.
+func (r *Ranker) sosGames(teamList TeamList) ([]database.Game, error) {
+	if len(teamList) == 0 {
+		return nil, nil
+	}
+
+	var teams []int64
+	for id := range teamList {
+		teams = append(teams, id)
+	}
+
+	return r.rankingGames(gameQuery{
+		sport:     r.sportFilter(),
+		minSeason: r.Year,
+		maxSeason: r.Year,
+		before:    r.startTime,
+		teams:     teams,
+	})
+}
+
+// rateSOS sets each team's SOS, SOSRank and SOSNorm from gameList.
+func rateSOS(teamList TeamList, gameList []database.Game) error {
 	// range order over a map is not deterministic, so create a slice to ensure
 	// order when creating vectors/matrices for SoE
 	var teamOrder []int64
 	for id := range teamList {
 		teamOrder = append(teamOrder, id)
 	}
+	slices.Sort(teamOrder)
 
 	teamOrderMap := map[int64]int{}
 	for idx, team := range teamOrder {
 		teamOrderMap[team] = idx
 	}
 
-	var gameList []database.Game
-	if err := r.DB.
-		Where(
-			"sport = ? and season = ? and start_time <= ? and home_id in (?) and away_id in (?)",
-			r.sportFilter(), r.Year, r.startTime, teamOrder, teamOrder,
-		).
-		Order("start_time desc").Find(&gameList).Error; err != nil {
-		return err
-	}
-
 	teamGameInfo := map[int64][]*gameResults{}
 	for _, game := range gameList {
 		teamGameInfo[game.HomeID] = append(teamGameInfo[game.HomeID], &gameResults{
@@ -136,13 +166,90 @@ func (r *Ranker) sos(teamList TeamList) error {
 	return nil
 }
 
-type gameSpreadSRS struct {
-	team     int64
-	spread   int64
-	opponent int64
+func (r *Ranker) srs(teamList TeamList) error {
+	games, err := r.srsGames(teamList)
+	if err != nil {
+		return err
+	}
+
+	r.rateSRS(teamList, games)
+
+	return nil
 }
 
-func (r *Ranker) srs(teamList TeamList) error {
+// rateSRS sets each team's SRS, SRSRank and SRSNorm from games: the average
+// over the sport's MOV caps of the normalized SRS ratings. The ratings at the
+// lowest and highest caps are kept in SRSLow and SRSHigh.
+func (r *Ranker) rateSRS(teamList TeamList, games []database.Game) {
+	cfg := r.sportConfig()
+
+	for _, team := range teamList {
+		team.SRS = 0
+		team.SRSLow, team.SRSLowNorm = 0, 0
+		team.SRSHigh, team.SRSHighNorm = 0, 0
+	}
+
+	for i, mov := range cfg.MOVCaps {
+		ratings := generateAdjRatings(games, r.srsParams(mov)).ratings
+		maxMOV := math.Inf(-1)
+		minMOV := math.Inf(1)
+		for _, rating := range ratings {
+			if rating > maxMOV {
+				maxMOV = rating
+			}
+			if rating < minMOV {
+				minMOV = rating
+			}
+		}
+		for id, rating := range ratings {
+			team := teamList[id]
+			norm := (rating - minMOV) / (maxMOV - minMOV)
+			team.SRS = ((team.SRS * float64(i)) + norm) / float64(i+1)
+
+			if i == 0 {
+				team.SRSLow, team.SRSLowNorm = rating, norm
+			}
+			if i == len(cfg.MOVCaps)-1 {
+				team.SRSHigh, team.SRSHighNorm = rating, norm
+			}
+		}
+	}
+
+	var teamIDs []int64
+	for id := range teamList {
+		teamIDs = append(teamIDs, id)
+	}
+	sort.Slice(teamIDs, func(i, j int) bool {
+		return teamList[teamIDs[i]].SRS > teamList[teamIDs[j]].SRS
+	})
+	maxSRS := teamList[teamIDs[0]].SRS
+	minSRS := teamList[teamIDs[len(teamIDs)-1]].SRS
+	var prev float64
+	var prevRank int64
+	for rank, id := range teamIDs {
+		team := teamList[id]
+
+		if team.SRS == prev {
+			team.SRSRank = prevRank
+		} else {
+			team.SRSRank = int64(rank + 1)
+			prev = team.SRS
+			prevRank = team.SRSRank
+		}
+		if maxSRS-minSRS > 0 {
+			team.SRSNorm = (team.SRS - minSRS) / (maxSRS - minSRS)
+		}
+	}
+}
+
+// srsGames returns the games SRS is fit to: this season's games between
+// division-mates, backfilled from earlier seasons until every team has
+// RequiredGames.
+func (r *Ranker) srsGames(teamList TeamList) ([]database.Game, error) {
+	if len(teamList) == 0 {
+		return nil, nil
+	}
+
 	cfg := r.sportConfig()
 	sport := r.sportFilter()
 
@@ -151,39 +258,38 @@ func (r *Ranker) srs(teamList TeamList) error {
 	for id := range teamList {
 		allowedTeams = append(allowedTeams, id)
 	}
-	var allGames []database.Game
-	if err := r.DB.
-		Where(
-			"sport = ? and season >= ? and start_time <= ? and home_id in (?) and away_id in (?)",
-			sport,
-			r.Year-cfg.YearsBack,
-			r.startTime,
-			allowedTeams,
-			allowedTeams,
-		).
-		Order("start_time desc").Find(&allGames).Error; err != nil {
-		return err
+	allGames, err := r.rankingGames(gameQuery{
+		sport:     sport,
+		minSeason: r.Year - cfg.YearsBack,
+		before:    r.startTime,
+		teams:     allowedTeams,
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// games count toward RequiredGames by their recency weight, so older
+	// seasons fade out rather than counting fully until they drop off
+	required := float64(cfg.RequiredGames)
 	var games []database.Game
 	found := make(map[int64]bool)
 	for id := range teamList {
-		divGames := 0
+		var divGames float64
 		for _, game := range allGames {
 			if game.Season == r.Year {
 				if (game.HomeID == id && teamList.teamExists(game.AwayID)) ||
 					(game.AwayID == id && teamList.teamExists(game.HomeID)) {
-					divGames++
+					divGames += recencyWeight(game, r.startTime, cfg.HalfLifeDays)
 					if !found[game.GameID] {
 						games = append(games, game)
 						found[game.GameID] = true
 					}
 				}
 			} else {
-				if divGames < cfg.RequiredGames {
+				if divGames < required {
 					if (game.HomeID == id && teamList.teamExists(game.AwayID)) ||
 						(game.AwayID == id && teamList.teamExists(game.HomeID)) {
-						divGames++
+						divGames += recencyWeight(game, r.startTime, cfg.HalfLifeDays)
 						if !found[game.GameID] {
 							games = append(games, game)
 							found[game.GameID] = true
@@ -203,21 +309,16 @@ func (r *Ranker) srs(teamList TeamList) error {
 			of games but all wins throwing off the rating scale. For teams in this situation
 			we can individually search for their remaining games against division-mates.
 		*/
-		if divGames < cfg.RequiredGames {
-			var remainingGames []database.Game
-			if err := r.DB.
-				Where(
-					"sport = ? and season < ? and ((home_id = ? and away_id in (?)) or "+
-						"(away_id = ? and home_id in (?)))",
-					sport,
-					r.Year-cfg.YearsBack,
-					id,
-					allowedTeams,
-					id,
-					allowedTeams,
-				).Limit(cfg.RequiredGames - divGames).Order("start_time desc").
-				Find(&remainingGames).Error; err != nil {
-				return err
+		if divGames < required {
+			remainingGames, err := r.rankingGames(gameQuery{
+				sport:     sport,
+				maxSeason: r.Year - cfg.YearsBack - 1,
+				team:      id,
+				teams:     allowedTeams,
+				limit:     int(math.Ceil(required - divGames)),
+			})
+			if err != nil {
+				return nil, err
 			}
 			for _, game := range remainingGames {
 				if !found[game.GameID] {
@@ -228,104 +329,283 @@ func (r *Ranker) srs(teamList TeamList) error {
 		}
 	}
 
-	for i, mov := range cfg.MOVCaps {
-		ratings := generateAdjRatings(games, mov)
-		maxMOV := math.Inf(-1)
-		minMOV := math.Inf(1)
-		for _, rating := range ratings {
-			if rating > maxMOV {
-				maxMOV = rating
-			}
-			if rating < minMOV {
-				minMOV = rating
-			}
-		}
-		for id, rating := range ratings {
-			team := teamList[id]
-			norm := (rating - minMOV) / (maxMOV - minMOV)
-			team.SRS = ((team.SRS * float64(i)) + norm) / float64(i+1)
+	// teams are visited in map order, and weighted sums depend on game order
+	slices.SortFunc(games, func(a, b database.Game) int { return cmp.Compare(a.GameID, b.GameID) })
+
+	return games, nil
+}
+
+// homeFieldMode selects how generateAdjRatings accounts for home-field
+// advantage.
+type homeFieldMode int
+
+const (
+	homeFieldNone     homeFieldMode = iota // treat every game as neutral
+	homeFieldPooled                        // one advantage across all games
+	homeFieldBySeason                      // one advantage per season, pooled when a season is thin
+)
+
+// minHomeFieldGames is the fewest non-neutral games needed to estimate a
+// home-field advantage. Smaller samples say more about the teams involved than
+// about where the games were played.
+const minHomeFieldGames = 50
+
+type srsParams struct {
+	mov       int64
+	homeField homeFieldMode
+	// asOf and halfLifeDays weight games by recency; see recencyWeight.
+	asOf         time.Time
+	halfLifeDays float64
+}
+
+// srsParams returns the SRS fit parameters for one MOV cap, weighting games
+// by their age at the ranking cutoff.
+func (r *Ranker) srsParams(mov int64) srsParams {
+	cfg := r.sportConfig()
+	return srsParams{mov: mov, homeField: cfg.HomeField, asOf: r.startTime, halfLifeDays: cfg.HalfLifeDays}
+}
+
+// recencyWeight is how much a game counts toward SRS: 1 at asOf, halving
+// every halfLifeDays before it. A zero half-life counts every game fully.
+func recencyWeight(game database.Game, asOf time.Time, halfLifeDays float64) float64 {
+	if halfLifeDays <= 0 {
+		return 1
+	}
+	days := asOf.Sub(game.StartTime).Hours() / 24
+	if days <= 0 {
+		return 1
+	}
+	return math.Exp2(-days / halfLifeDays)
+}
+
+type srsResult struct {
+	ratings map[int64]float64
+	homeAdv homeAdvantage
+}
+
+// homeAdvantage is the estimated margin, in capped points, that the home team
+// gains from playing at home.
+type homeAdvantage struct {
+	pooled   float64
+	bySeason map[int64]float64
+}
+
+// forGame returns the advantage the home team had in game. Neutral-site games
+// have none.
+func (h homeAdvantage) forGame(game database.Game) float64 {
+	if game.Neutral {
+		return 0
+	}
+	if hfa, ok := h.bySeason[game.Season]; ok {
+		return hfa
+	}
+	return h.pooled
+}
+
+// within reports whether two estimates differ by no more than tol for the
+// pooled advantage and every season.
+func (h homeAdvantage) within(other homeAdvantage, tol float64) bool {
+	if math.Abs(h.pooled-other.pooled) > tol || len(h.bySeason) != len(other.bySeason) {
+		return false
+	}
+	for season, hfa := range h.bySeason {
+		otherHFA, ok := other.bySeason[season]
+		if !ok || math.Abs(hfa-otherHFA) > tol {
+			return false
 		}
 	}
+	return true
+}
 
-	var teamIDs []int64
-	for id := range teamList {
-		teamIDs = append(teamIDs, id)
+// estimateHomeAdvantage measures how far home teams outperform the rating
+// difference between the two teams. Seasons without enough games fall back to
+// the pooled estimate, and with too few games overall no advantage is assumed.
+func estimateHomeAdvantage(
+	games []database.Game,
+	spreads []int64,
+	weights []float64,
+	ratings map[int64]float64,
+	mode homeFieldMode,
+) homeAdvantage {
+	var hfa homeAdvantage
+	if mode == homeFieldNone {
+		return hfa
 	}
-	sort.Slice(teamIDs, func(i, j int) bool {
-		return teamList[teamIDs[i]].SRS > teamList[teamIDs[j]].SRS
-	})
-	maxSRS := teamList[teamIDs[0]].SRS
-	minSRS := teamList[teamIDs[len(teamIDs)-1]].SRS
-	var prev float64
-	var prevRank int64
-	for rank, id := range teamIDs {
-		team := teamList[id]
 
-		if team.SRS == prev {
-			team.SRSRank = prevRank
-		} else {
-			team.SRSRank = int64(rank + 1)
-			prev = team.SRS
-			prevRank = team.SRSRank
+	var total, weight float64
+	var count int
+	seasonTotal := map[int64]float64{}
+	seasonWeight := map[int64]float64{}
+	seasonCount := map[int64]int{}
+	for i, game := range games {
+		if game.Neutral {
+			continue
 		}
-		if maxSRS-minSRS > 0 {
-			team.SRSNorm = (team.SRS - minSRS) / (maxSRS - minSRS)
+		residual := float64(spreads[i]) - (ratings[game.HomeID] - ratings[game.AwayID])
+		total += weights[i] * residual
+		weight += weights[i]
+		count++
+		seasonTotal[game.Season] += weights[i] * residual
+		seasonWeight[game.Season] += weights[i]
+		seasonCount[game.Season]++
+	}
+	if count < minHomeFieldGames {
+		return hfa
+	}
+	hfa.pooled = total / weight
+
+	if mode == homeFieldBySeason {
+		hfa.bySeason = map[int64]float64{}
+		for season, n := range seasonCount {
+			if n >= minHomeFieldGames {
+				hfa.bySeason[season] = seasonTotal[season] / seasonWeight[season]
+			}
 		}
 	}
 
-	return nil
+	return hfa
 }
 
-func generateAdjRatings(games []database.Game, mov int64) map[int64]float64 {
-	teamGameInfo := map[int64][]*gameSpreadSRS{}
+// generateAdjRatings solves for the ratings that best explain the capped game
+// spreads in the least-squares sense: each team's rating is its average spread
+// plus the average rating of its opponents. The normal equations form a graph
+// Laplacian, which is singular by one degree of freedom per connected group of
+// teams, so a zero-sum constraint is added and the system is solved with a
+// Cholesky factorization. If the games do not connect every team the
+// factorization fails and a damped fixed-point iteration is used instead.
+//
+// Games are weighted by recency (see recencyWeight), so the averages are
+// weighted averages and older games pull less on the ratings.
+//
+// The home-field advantage depends on the ratings and vice versa, so the two
+// are refined in turn. Only the right-hand side changes between passes, so the
+// factorization is reused.
+func generateAdjRatings(games []database.Game, params srsParams) srsResult {
+	mov := params.mov
+
+	// map iteration order is not deterministic, so index teams in sorted order
+	var teams []int64
+	teamIdx := map[int64]int{}
 	for _, game := range games {
+		for _, id := range []int64{game.HomeID, game.AwayID} {
+			if _, ok := teamIdx[id]; !ok {
+				teamIdx[id] = -1
+				teams = append(teams, id)
+			}
+		}
+	}
+	slices.Sort(teams)
+	for idx, id := range teams {
+		teamIdx[id] = idx
+	}
+
+	numTeams := len(teams)
+	if numTeams == 0 {
+		return srsResult{ratings: map[int64]float64{}}
+	}
+
+	spreads := make([]int64, len(games))
+	weights := make([]float64, len(games))
+	laplacian := mat.NewSymDense(numTeams, nil)
+	for i, game := range games {
 		spread := game.HomeScore - game.AwayScore
 		if spread > mov {
 			spread = mov
 		} else if spread < -mov {
 			spread = -mov
 		}
+		spreads[i] = spread
+		weight := recencyWeight(game, params.asOf, params.halfLifeDays)
+		weights[i] = weight
+
+		home, away := teamIdx[game.HomeID], teamIdx[game.AwayID]
+		laplacian.SetSym(home, home, laplacian.At(home, home)+weight)
+		laplacian.SetSym(away, away, laplacian.At(away, away)+weight)
+		laplacian.SetSym(home, away, laplacian.At(home, away)-weight)
+	}
 
-		teamGameInfo[game.HomeID] = append(teamGameInfo[game.HomeID], &gameSpreadSRS{
-			team:     game.HomeID,
-			spread:   spread,
-			opponent: game.AwayID,
-		})
-		teamGameInfo[game.AwayID] = append(teamGameInfo[game.AwayID], &gameSpreadSRS{
-			team:     game.AwayID,
-			spread:   -spread,
-			opponent: game.HomeID,
-		})
+	// weighted sum of each team's spreads with the home-field edge taken out
+	spreadTotals := func(hfa homeAdvantage) *mat.VecDense {
+		totals := mat.NewVecDense(numTeams, nil)
+		for i, game := range games {
+			adjusted := weights[i] * (float64(spreads[i]) - hfa.forGame(game))
+			home, away := teamIdx[game.HomeID], teamIdx[game.AwayID]
+			totals.SetVec(home, totals.AtVec(home)+adjusted)
+			totals.SetVec(away, totals.AtVec(away)-adjusted)
+		}
+		return totals
 	}
 
-	ratings := map[int64]float64{}
-	for id, spreads := range teamGameInfo {
-		var avg int64
-		for _, spread := range spreads {
-			avg += spread.spread
+	constrained := mat.NewSymDense(numTeams, nil)
+	for i := range numTeams {
+		for j := i; j < numTeams; j++ {
+			constrained.SetSym(i, j, laplacian.At(i, j)+1)
 		}
-		ratings[id] = float64(avg) / float64(len(spreads))
 	}
 
-	adjRatings := ratings
-	for i := 0; i < runs; i++ { // guard against oscillating by capping runs
-		nextRating := map[int64]float64{}
-		for id, games := range teamGameInfo {
-			var oppAvg float64
-			for _, game := range games {
-				oppAvg += adjRatings[game.opponent]
+	var chol mat.Cholesky
+	solve := func(b *mat.VecDense) *mat.VecDense {
+		return iterateRatings(laplacian, b)
+	}
+	if chol.Factorize(constrained) {
+		solve = func(b *mat.VecDense) *mat.VecDense {
+			var x mat.VecDense
+			if err := chol.SolveVecTo(&x, b); err != nil {
+				return iterateRatings(laplacian, b)
 			}
-			oppAvg /= float64(len(games))
-			nextRating[id] = ratings[id] + oppAvg
+			return &x
+		}
+	}
+
+	var hfa homeAdvantage
+	var adjRatings map[int64]float64
+	for range runs {
+		x := solve(spreadTotals(hfa))
+		adjRatings = make(map[int64]float64, numTeams)
+		for idx, id := range teams {
+			adjRatings[id] = x.AtVec(idx)
 		}
 
-		// when they stop changing, we've peaked
-		if reflect.DeepEqual(adjRatings, nextRating) {
+		nextHFA := estimateHomeAdvantage(games, spreads, weights, adjRatings, params.homeField)
+		if nextHFA.within(hfa, srsTolerance) {
 			break
 		}
-		adjRatings = nextRating
+		hfa = nextHFA
 	}
 	delete(adjRatings, 0)
 
-	return adjRatings
+	return srsResult{ratings: adjRatings, homeAdv: hfa}
+}
+
+// iterateRatings solves laplacian * x = b by damped Jacobi iteration. Unlike
+// the Cholesky solve it tolerates groups of teams that never play each other,
+// each of which is rated on its own scale. Damping keeps two-team groups from
+// oscillating between solutions.
+func iterateRatings(laplacian *mat.SymDense, b *mat.VecDense) *mat.VecDense {
+	n := b.Len()
+	x := mat.NewVecDense(n, nil)
+	next := mat.NewVecDense(n, nil)
+	for range runs {
+		var maxDelta float64
+		for i := range n {
+			degree := laplacian.At(i, i)
+			if degree == 0 {
+				continue
+			}
+			sum := b.AtVec(i)
+			for j := range n {
+				if j != i {
+					sum -= laplacian.At(i, j) * x.AtVec(j)
+				}
+			}
+			value := 0.5*x.AtVec(i) + 0.5*sum/degree
+			maxDelta = math.Max(maxDelta, math.Abs(value-x.AtVec(i)))
+			next.SetVec(i, value)
+		}
+		x, next = next, x
+		if maxDelta < srsTolerance {
+			break
+		}
+	}
+	return x
 }
@@ -4,6 +4,8 @@ import (
 	"math"
 	"testing"
 	"time"
+
+	"github.com/robby-barton/stats-go/internal/database"
 )
 
 func TestSRS_BasicRanking(t *testing.T) {
@@ -158,3 +160,134 @@ func TestCalculateRanking_FullPipeline(t *testing.T) {
 		}
 	}
 }
+
+func TestCalculateRanking_Unified(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball, Unified: true, Resamples: 50}
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	// Epsilon, the only FCS team, is rated through its games against FBS.
+	if len(teamList) != 5 {
+		t.Fatalf("len(teamList) = %d, want 5", len(teamList))
+	}
+	epsilon := teamList[5]
+	if epsilon.Division != database.DivisionFCS || epsilon.FinalRank != 1 ||
+		epsilon.RankBest != 1 || epsilon.RankWorst != 1 {
+		t.Errorf("Epsilon Division/FinalRank/range = %s/%d/%d-%d, want fcs/1/1-1",
+			epsilon.Division, epsilon.FinalRank, epsilon.RankBest, epsilon.RankWorst)
+	}
+	if teamList[1].OverallRank != 1 {
+		t.Errorf("Alpha OverallRank = %d, want 1", teamList[1].OverallRank)
+	}
+
+	// FBS teams keep their order across divisions, ranked 1-4 among themselves.
+	fbs := map[int64]bool{}
+	for id, team := range teamList {
+		if team.OverallRank < 1 || team.OverallRank > 5 {
+			t.Errorf("team %d OverallRank = %d, want [1,5]", id, team.OverallRank)
+		}
+		if team.Division != database.DivisionFBS {
+			continue
+		}
+		fbs[team.FinalRank] = true
+		if team.RankWorst > 4 {
+			t.Errorf("team %d RankWorst = %d, want <= 4 within FBS", id, team.RankWorst)
+		}
+		for otherID, other := range teamList {
+			if other.Division == database.DivisionFBS &&
+				(team.OverallRank < other.OverallRank) != (team.FinalRank < other.FinalRank) {
+				t.Errorf("teams %d and %d: overall %d/%d, division %d/%d",
+					id, otherID, team.OverallRank, other.OverallRank, team.FinalRank, other.FinalRank)
+			}
+		}
+	}
+	if len(fbs) != 4 {
+		t.Errorf("FBS ranks = %v, want 1-4", fbs)
+	}
+
+	// Division-only rankings leave OverallRank unset.
+	plain, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	for id, team := range plain {
+		if team.OverallRank != 0 || team.Division != database.DivisionFBS {
+			t.Errorf("team %d OverallRank/Division = %d/%s, want 0/fbs", id, team.OverallRank, team.Division)
+		}
+	}
+}
+
+func TestCalculateRanking_DivisionII(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	teamNames := []database.TeamName{
+		{TeamID: 7, Name: "Eta", Sport: "ncaaf"},
+		{TeamID: 8, Name: "Theta", Sport: "ncaaf"},
+		{TeamID: 9, Name: "Iota", Sport: "ncaaf"},
+	}
+	teamSeasons := []database.TeamSeason{
+		{TeamID: 7, Year: 2023, Division: database.DivisionII, Conf: "GLIAC", Sport: "ncaaf"},
+		{TeamID: 8, Year: 2023, Division: database.DivisionII, Conf: "GLIAC", Sport: "ncaaf"},
+		{TeamID: 9, Year: 2023, Division: database.DivisionII, Conf: "GLIAC", Sport: "ncaaf"},
+	}
+	base := time.Date(2023, 9, 2, 19, 0, 0, 0, time.UTC)
+	games := []database.Game{
+		{GameID: 3001, Season: 2023, Week: 1, HomeID: 7, AwayID: 8, HomeScore: 31, AwayScore: 10, Sport: "ncaaf",
+			StartTime: base},
+		{GameID: 3002, Season: 2023, Week: 2, HomeID: 8, AwayID: 9, HomeScore: 24, AwayScore: 17, Sport: "ncaaf",
+			StartTime: base.AddDate(0, 0, 7)},
+		{GameID: 3003, Season: 2023, Week: 3, HomeID: 9, AwayID: 7, HomeScore: 3, AwayScore: 28, Sport: "ncaaf",
+			StartTime: base.AddDate(0, 0, 14)},
+		// against FCS, outside the division
+		{GameID: 3004, Season: 2023, Week: 4, HomeID: 5, AwayID: 9, HomeScore: 10, AwayScore: 42, Sport: "ncaaf",
+			StartTime: base.AddDate(0, 0, 21)},
+	}
+	for _, rows := range []any{&teamNames, &teamSeasons, &games} {
+		if err := db.Create(rows).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	teamList, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball, Division: database.DivisionII}).
+		CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	if len(teamList) != 3 {
+		t.Fatalf("len(teamList) = %d, want 3", len(teamList))
+	}
+	for id, team := range teamList {
+		if team.Division != database.DivisionII {
+			t.Errorf("team %d Division = %s, want dii", id, team.Division)
+		}
+	}
+	// Eta is unbeaten in the division; Iota's win over FCS doesn't lift it.
+	if teamList[7].FinalRank != 1 || teamList[9].FinalRank != 3 {
+		t.Errorf("Eta, Iota FinalRank = %d, %d, want 1, 3", teamList[7].FinalRank, teamList[9].FinalRank)
+	}
+
+	// The other divisions are unchanged.
+	fbs, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	if len(fbs) != 4 {
+		t.Errorf("FBS teams = %d, want 4", len(fbs))
+	}
+
+	// A division without teams ranks nothing.
+	diii, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball, Division: database.DivisionIII}).
+		CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	if len(diii) != 0 {
+		t.Errorf("DIII teams = %d, want 0", len(diii))
+	}
+}
@@ -1,21 +1,15 @@
 package ranking
 
-import (
-	"github.com/robby-barton/stats-go/internal/database"
-)
-
 func (r *Ranker) record(teamList TeamList) error {
 	sport := r.sportFilter()
 
-	var games []database.Game
-	if err := r.DB.Where("sport = ? and season = ? and start_time <= ?", sport, r.Year, r.startTime).
-		Find(&games).Error; err != nil {
+	games, err := r.rankingGames(gameQuery{sport: sport, minSeason: r.Year, maxSeason: r.Year, before: r.startTime})
+	if err != nil {
 		return err
 	}
 
-	var allTeams []int64
-	if err := r.DB.Model(database.TeamSeason{}).Where("sport = ? and year = ?", sport, r.Year).
-		Pluck("team_id", &allTeams).Error; err != nil {
+	allTeams, err := r.gameSource().seasonTeamIDs(sport, r.Year)
+	if err != nil {
 		return err
 	}
 
@@ -37,8 +31,7 @@ func (r *Ranker) record(teamList TeamList) error {
 			default:
 				homeRecord.Ties++
 			}
-			homeRecord.Record = (1 + float64(homeRecord.Wins) + 0.5*float64(homeRecord.Ties)) /
-				(2 + float64(homeRecord.Wins+homeRecord.Losses+homeRecord.Ties))
+			homeRecord.Record = recordScore(homeRecord.Wins, homeRecord.Losses, homeRecord.Ties)
 		}
 		if allowedTeam[game.AwayID] {
 			awayRecord := teamRecords[game.AwayID]
@@ -50,8 +43,7 @@ func (r *Ranker) record(teamList TeamList) error {
 			default:
 				awayRecord.Ties++
 			}
-			awayRecord.Record = (1 + float64(awayRecord.Wins) + 0.5*float64(awayRecord.Ties)) /
-				(2 + float64(awayRecord.Wins+awayRecord.Losses+awayRecord.Ties))
+			awayRecord.Record = recordScore(awayRecord.Wins, awayRecord.Losses, awayRecord.Ties)
 		}
 	}
 
@@ -63,3 +55,9 @@ func (r *Ranker) record(teamList TeamList) error {
 
 	return nil
 }
+
+// recordScore is the win percentage with one phantom win and one phantom loss
+// added, so a 1-0 team doesn't outrank an 11-1 team on record alone.
+func recordScore(wins int64, losses int64, ties int64) float64 {
+	return (1 + float64(wins) + 0.5*float64(ties)) / (2 + float64(wins+losses+ties))
+}
@@ -7,18 +7,23 @@ import (
 )
 
 func (r *Ranker) setup() (TeamList, error) {
+	// drop a previous run's overlay before reading from the source
+	r.source = nil
 	if err := r.setGlobals(); err != nil {
 		return nil, err
 	}
 
+	if err := r.overlay(); err != nil {
+		return nil, err
+	}
+
 	var teamList TeamList
 	var err error
 
-	// FCS ranking only applies to football; basketball has no division split.
-	if r.Fcs && r.Sport != sportBasketball {
-		teamList, err = r.createTeamList(0)
+	if r.unified() {
+		teamList, err = r.createTeamList(database.DivisionFBS, database.DivisionFCS)
 	} else {
-		teamList, err = r.createTeamList(1)
+		teamList, err = r.createTeamList(r.division())
 	}
 	if err != nil {
 		return nil, err
@@ -29,12 +34,11 @@ func (r *Ranker) setup() (TeamList, error) {
 
 func (r *Ranker) setGlobals() error {
 	sport := r.sportFilter()
+	source := r.gameSource()
 
 	if r.Year == 0 {
-		var year int64
-		if err := r.DB.Model(database.TeamSeason{}).
-			Where("sport = ?", sport).
-			Select("max(year) as year").Pluck("year", &year).Error; err != nil {
+		year, err := source.latestYear(sport)
+		if err != nil {
 			return err
 		}
 		r.Year = year
@@ -42,11 +46,8 @@ func (r *Ranker) setGlobals() error {
 
 	var game database.Game
 	if r.Week > 0 {
-		if err := r.DB.
-			Where("sport = ? and season = ? and week = ? and postseason = 0", sport, r.Year, r.Week).
-			Order("start_time asc").
-			Limit(1).
-			Find(&game).Error; err != nil {
+		var err error
+		if game, err = source.weekStart(sport, r.Year, r.Week); err != nil {
 			return err
 		}
 		if game != (database.Game{}) {
@@ -59,13 +60,13 @@ func (r *Ranker) setGlobals() error {
 	}
 
 	if game == (database.Game{}) {
-		if err := r.DB.
-			Where("sport = ? and season <= ?", sport, r.Year).
-			Order("start_time desc").
-			Limit(1).
-			Find(&game).Error; err != nil {
+		latest, err := source.games(gameQuery{sport: sport, maxSeason: r.Year, limit: 1})
+		if err != nil {
 			return err
 		}
+		if len(latest) > 0 {
+			game = latest[0]
+		}
 	}
 
 	if game.Season < r.Year {
@@ -87,32 +88,25 @@ func (r *Ranker) setGlobals() error {
 	return nil
 }
 
-func (r *Ranker) createTeamList(findFbs int64) (TeamList, error) {
-	teams := []struct {
-		TeamID int64
-		Name   string
-		Conf   string
-	}{}
-
-	if err := r.DB.Model(&database.TeamSeason{}).
-		Select("team_names.team_id, team_names.name, team_seasons.conf").
-		Joins("join team_names on team_seasons.team_id = team_names.team_id and team_seasons.sport = team_names.sport").
-		Where("team_seasons.fbs = ? and team_seasons.year = ? and team_seasons.sport = ?",
-			findFbs, r.Year, r.sportFilter()).
-		Scan(&teams).Error; err != nil {
-		return nil, err
-	}
-
+func (r *Ranker) createTeamList(divisions ...database.Division) (TeamList, error) {
 	teamList := TeamList{}
-	for _, team := range teams {
-		teamList[team.TeamID] = &Team{
-			Name: team.Name,
-			Conf: team.Conf,
-			Year: r.Year,
-			Week: r.Week,
+	for _, division := range divisions {
+		teams, err := r.gameSource().divisionTeams(r.sportFilter(), r.Year, division)
+		if err != nil {
+			return nil, err
 		}
-		if r.postseason {
-			teamList[team.TeamID].Postseason = 1
+
+		for _, team := range teams {
+			teamList[team.TeamID] = &Team{
+				Name:     team.Name,
+				Conf:     team.Conf,
+				Division: division,
+				Year:     r.Year,
+				Week:     r.Week,
+			}
+			if r.postseason {
+				teamList[team.TeamID].Postseason = 1
+			}
 		}
 	}
 
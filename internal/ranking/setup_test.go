@@ -92,7 +92,7 @@ func TestCreateTeamList_FBS(t *testing.T) {
 	seedTestData(t, db)
 
 	r := &Ranker{DB: db, Year: 2023, Week: 6, Sport: sportFootball}
-	teamList, err := r.createTeamList(1)
+	teamList, err := r.createTeamList(database.DivisionFBS)
 	if err != nil {
 		t.Fatalf("createTeamList: %v", err)
 	}
@@ -131,7 +131,7 @@ func TestCreateTeamList_FCS(t *testing.T) {
 	seedTestData(t, db)
 
 	r := &Ranker{DB: db, Year: 2023, Week: 6, Sport: sportFootball}
-	teamList, err := r.createTeamList(0)
+	teamList, err := r.createTeamList(database.DivisionFCS)
 	if err != nil {
 		t.Fatalf("createTeamList: %v", err)
 	}
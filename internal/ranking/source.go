@@ -0,0 +1,203 @@
+package ranking
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// gameQuery selects final games of one sport. Zero fields don't filter.
+type gameQuery struct {
+	sport     string
+	minSeason int64     // season >= minSeason
+	maxSeason int64     // season <= maxSeason
+	before    time.Time // start_time <= before
+	teams     []int64   // both teams are in teams
+	team      int64     // one of the teams is team, and the other is in teams
+	limit     int       // at most limit games, newest first
+}
+
+// matcher returns a function reporting whether a game is selected by q,
+// ignoring limit.
+func (q gameQuery) matcher() func(game database.Game) bool {
+	var teams map[int64]bool
+	if q.teams != nil {
+		teams = make(map[int64]bool, len(q.teams))
+		for _, id := range q.teams {
+			teams[id] = true
+		}
+	}
+
+	return func(game database.Game) bool {
+		if game.Sport != q.sport || game.Status != database.GameFinal {
+			return false
+		}
+		if q.minSeason != 0 && game.Season < q.minSeason {
+			return false
+		}
+		if q.maxSeason != 0 && game.Season > q.maxSeason {
+			return false
+		}
+		if !q.before.IsZero() && game.StartTime.After(q.before) {
+			return false
+		}
+		if q.team != 0 {
+			switch q.team {
+			case game.HomeID:
+				return teams == nil || teams[game.AwayID]
+			case game.AwayID:
+				return teams == nil || teams[game.HomeID]
+			default:
+				return false
+			}
+		}
+		if teams != nil {
+			return teams[game.HomeID] && teams[game.AwayID]
+		}
+		return true
+	}
+}
+
+// divisionTeam is a team ranked in a division for one season.
+type divisionTeam struct {
+	TeamID int64
+	Name   string
+	Conf   string
+}
+
+// GameSource supplies the games and team seasons a ranking is computed from.
+// A nil Ranker.Games reads them from the database on every ranking;
+// LoadGameSource reads them once for ranking many weeks.
+type GameSource interface {
+	// games returns the games matching q, newest first.
+	games(q gameQuery) ([]database.Game, error)
+	// weekStart returns the earliest regular-season game of a week in any
+	// status, or a zero Game if the week has none.
+	weekStart(sport string, season int64, week int64) (database.Game, error)
+	// latestYear returns the latest year with team seasons.
+	latestYear(sport string) (int64, error)
+	// divisionTeams returns the named teams of a division in a season.
+	divisionTeams(sport string, year int64, division database.Division) ([]divisionTeam, error)
+	// seasonTeamIDs returns every team with a season in year.
+	seasonTeamIDs(sport string, year int64) ([]int64, error)
+	// eloRatings returns each team's Elo rating after its latest game that
+	// started at or before before, or nil if the sport has no Elo history.
+	eloRatings(sport string, before time.Time) (map[int64]eloState, error)
+}
+
+// gameSource returns where the Ranker reads games and teams from: the
+// hypothetical overlay when one is set, then Games, then the database.
+func (r *Ranker) gameSource() GameSource {
+	switch {
+	case r.source != nil:
+		return r.source
+	case r.Games != nil:
+		return r.Games
+	default:
+		return dbGameSource{db: r.DB}
+	}
+}
+
+// rankingGames returns the games matching q from the Ranker's game source.
+func (r *Ranker) rankingGames(q gameQuery) ([]database.Game, error) {
+	return r.gameSource().games(q)
+}
+
+// dbGameSource reads games and teams straight from the database.
+type dbGameSource struct {
+	db *gorm.DB
+}
+
+func (s dbGameSource) games(q gameQuery) ([]database.Game, error) {
+	tx := s.db.Where("sport = ? and status = ?", q.sport, database.GameFinal)
+	if q.minSeason != 0 {
+		tx = tx.Where("season >= ?", q.minSeason)
+	}
+	if q.maxSeason != 0 {
+		tx = tx.Where("season <= ?", q.maxSeason)
+	}
+	if !q.before.IsZero() {
+		tx = tx.Where("start_time <= ?", q.before)
+	}
+	switch {
+	case q.team != 0 && q.teams != nil:
+		tx = tx.Where("((home_id = ? and away_id in (?)) or (away_id = ? and home_id in (?)))",
+			q.team, q.teams, q.team, q.teams)
+	case q.team != 0:
+		tx = tx.Where("(home_id = ? or away_id = ?)", q.team, q.team)
+	case q.teams != nil:
+		tx = tx.Where("home_id in (?) and away_id in (?)", q.teams, q.teams)
+	}
+	if q.limit > 0 {
+		tx = tx.Limit(q.limit)
+	}
+
+	var games []database.Game
+	if err := tx.Order("start_time desc").Find(&games).Error; err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+func (s dbGameSource) weekStart(sport string, season int64, week int64) (database.Game, error) {
+	var game database.Game
+	if err := s.db.
+		Where("sport = ? and season = ? and week = ? and postseason = 0", sport, season, week).
+		Order("start_time asc").
+		Limit(1).
+		Find(&game).Error; err != nil {
+		return database.Game{}, err
+	}
+	return game, nil
+}
+
+func (s dbGameSource) latestYear(sport string) (int64, error) {
+	var year int64
+	if err := s.db.Model(database.TeamSeason{}).
+		Where("sport = ?", sport).
+		Select("max(year) as year").Pluck("year", &year).Error; err != nil {
+		return 0, err
+	}
+	return year, nil
+}
+
+func (s dbGameSource) divisionTeams(
+	sport string,
+	year int64,
+	division database.Division,
+) ([]divisionTeam, error) {
+	var teams []divisionTeam
+	if err := s.db.Model(&database.TeamSeason{}).
+		Select("team_names.team_id, team_names.name, team_seasons.conf").
+		Joins("join team_names on team_seasons.team_id = team_names.team_id and team_seasons.sport = team_names.sport").
+		Where("team_seasons.division = ? and team_seasons.year = ? and team_seasons.sport = ?", division, year, sport).
+		Scan(&teams).Error; err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (s dbGameSource) seasonTeamIDs(sport string, year int64) ([]int64, error) {
+	var ids []int64
+	if err := s.db.Model(database.TeamSeason{}).Where("sport = ? and year = ?", sport, year).
+		Pluck("team_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s dbGameSource) eloRatings(sport string, before time.Time) (map[int64]eloState, error) {
+	var historyRows int64
+	if err := s.db.Model(&database.EloHistory{}).
+		Where("sport = ?", sport).
+		Count(&historyRows).Error; err != nil {
+		return nil, err
+	}
+	if historyRows == 0 {
+		return nil, nil
+	}
+
+	return loadEloState(s.db.Where("sport = ? and start_time <= ?", sport, before))
+}
@@ -0,0 +1,128 @@
+package ranking
+
+import "sort"
+
+// strength sets each team's strength of victory and strength of loss from
+// one pass over the season's games between division-mates.
+func (r *Ranker) strength(teamList TeamList) error {
+	beat, lostTo, err := r.opponentResults(teamList)
+	if err != nil {
+		return err
+	}
+
+	sov(teamList, beat)
+	sol(teamList, lostTo)
+
+	return nil
+}
+
+// sov sets each team's strength of victory: the average SRS of the
+// division-mates it has beaten this season. Teams without a win get the bottom
+// of the scale.
+func sov(teamList TeamList, beat map[int64][]int64) {
+	for id, team := range teamList {
+		team.SOV = averageSRS(teamList, beat[id], 0)
+	}
+
+	rankStrength(teamList,
+		func(team *Team) float64 { return team.SOV },
+		func(team *Team, rank int64, norm float64) {
+			team.SOVRank = rank
+			team.SOVNorm = norm
+		},
+	)
+}
+
+// sol sets each team's strength of loss: the average SRS of the division-mates
+// it has lost to this season. A higher value means better losses. Teams without
+// a loss get the top of the scale since they have no bad losses.
+func sol(teamList TeamList, lostTo map[int64][]int64) {
+	for id, team := range teamList {
+		team.SOL = averageSRS(teamList, lostTo[id], 1)
+	}
+
+	rankStrength(teamList,
+		func(team *Team) float64 { return team.SOL },
+		func(team *Team, rank int64, norm float64) {
+			team.SOLRank = rank
+			team.SOLNorm = norm
+		},
+	)
+}
+
+// opponentResults returns the division-mates each team has beaten and lost to
+// this season. Ties count as neither.
+func (r *Ranker) opponentResults(teamList TeamList) (map[int64][]int64, map[int64][]int64, error) {
+	gameList, err := r.sosGames(teamList)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	beat := map[int64][]int64{}
+	lostTo := map[int64][]int64{}
+	for _, game := range gameList {
+		switch {
+		case game.HomeScore > game.AwayScore:
+			beat[game.HomeID] = append(beat[game.HomeID], game.AwayID)
+			lostTo[game.AwayID] = append(lostTo[game.AwayID], game.HomeID)
+		case game.AwayScore > game.HomeScore:
+			beat[game.AwayID] = append(beat[game.AwayID], game.HomeID)
+			lostTo[game.HomeID] = append(lostTo[game.HomeID], game.AwayID)
+		}
+	}
+
+	return beat, lostTo, nil
+}
+
+func averageSRS(teamList TeamList, opponents []int64, none float64) float64 {
+	if len(opponents) == 0 {
+		return none
+	}
+
+	var total float64
+	for _, opp := range opponents {
+		total += teamList[opp].SRS
+	}
+	return total / float64(len(opponents))
+}
+
+// rankStrength ranks teams by value, highest first, giving tied teams the same
+// rank, and min-max normalizes the values to [0,1].
+func rankStrength(
+	teamList TeamList,
+	value func(*Team) float64,
+	set func(team *Team, rank int64, norm float64),
+) {
+	var teamIDs []int64
+	for id := range teamList {
+		teamIDs = append(teamIDs, id)
+	}
+	if len(teamIDs) == 0 {
+		return
+	}
+	sort.Slice(teamIDs, func(i, j int) bool {
+		return value(teamList[teamIDs[i]]) > value(teamList[teamIDs[j]])
+	})
+
+	maxValue := value(teamList[teamIDs[0]])
+	minValue := value(teamList[teamIDs[len(teamIDs)-1]])
+	var prev float64
+	var prevRank int64
+	for rank, id := range teamIDs {
+		team := teamList[id]
+
+		teamRank := int64(rank + 1)
+		if rank > 0 && value(team) == prev {
+			teamRank = prevRank
+		} else {
+			prev = value(team)
+			prevRank = teamRank
+		}
+
+		var norm float64
+		if maxValue-minValue > 0 {
+			norm = (value(team) - minValue) / (maxValue - minValue)
+		}
+		set(team, teamRank, norm)
+	}
+}
@@ -0,0 +1,116 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func strengthTestRanker(t *testing.T) (*Ranker, TeamList) {
+	t.Helper()
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{
+		DB:        db,
+		Year:      2023,
+		Sport:     sportFootball,
+		startTime: time.Date(2023, 10, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	teamList := TeamList{
+		1: &Team{Name: "Alpha", SRS: 1.0},
+		2: &Team{Name: "Beta", SRS: 0.6},
+		3: &Team{Name: "Gamma", SRS: 0.4},
+		4: &Team{Name: "Delta", SRS: 0.0},
+	}
+
+	return r, teamList
+}
+
+func TestSOV(t *testing.T) {
+	r, teamList := strengthTestRanker(t)
+
+	if err := r.strength(teamList); err != nil {
+		t.Fatalf("strength: %v", err)
+	}
+
+	// Alpha beat Beta, Gamma and Delta; Beta beat Delta and Gamma; Gamma beat
+	// Delta and Beta; Delta has no wins. Games against FCS Epsilon don't count.
+	want := map[int64]struct {
+		sov  float64
+		rank int64
+	}{
+		1: {(0.6 + 0.4 + 0.0) / 3, 1},
+		2: {(0.0 + 0.4) / 2, 3},
+		3: {(0.0 + 0.6) / 2, 2},
+		4: {0, 4},
+	}
+	for id, w := range want {
+		team := teamList[id]
+		if math.Abs(team.SOV-w.sov) > 0.001 {
+			t.Errorf("%s SOV = %f, want %f", team.Name, team.SOV, w.sov)
+		}
+		if team.SOVRank != w.rank {
+			t.Errorf("%s SOVRank = %d, want %d", team.Name, team.SOVRank, w.rank)
+		}
+		if team.SOVNorm < 0 || team.SOVNorm > 1 {
+			t.Errorf("%s SOVNorm = %f, want [0,1]", team.Name, team.SOVNorm)
+		}
+	}
+	if teamList[1].SOVNorm != 1 || teamList[4].SOVNorm != 0 {
+		t.Errorf("SOVNorm range = [%f, %f], want [0, 1]", teamList[4].SOVNorm, teamList[1].SOVNorm)
+	}
+}
+
+func TestSOL(t *testing.T) {
+	r, teamList := strengthTestRanker(t)
+
+	if err := r.strength(teamList); err != nil {
+		t.Fatalf("strength: %v", err)
+	}
+
+	// Alpha is unbeaten; Beta lost to Alpha and Gamma; Gamma lost to Alpha and
+	// Beta; Delta lost to everyone. The Gamma-Delta tie counts for neither.
+	want := map[int64]struct {
+		sol  float64
+		rank int64
+	}{
+		1: {1, 1},
+		2: {(1.0 + 0.4) / 2, 3},
+		3: {(1.0 + 0.6) / 2, 2},
+		4: {(0.4 + 0.6 + 1.0) / 3, 4},
+	}
+	for id, w := range want {
+		team := teamList[id]
+		if math.Abs(team.SOL-w.sol) > 0.001 {
+			t.Errorf("%s SOL = %f, want %f", team.Name, team.SOL, w.sol)
+		}
+		if team.SOLRank != w.rank {
+			t.Errorf("%s SOLRank = %d, want %d", team.Name, team.SOLRank, w.rank)
+		}
+	}
+}
+
+func TestRankStrength_Ties(t *testing.T) {
+	teamList := TeamList{
+		1: &Team{SOV: 0.5},
+		2: &Team{SOV: 0.5},
+		3: &Team{SOV: 0},
+		4: &Team{SOV: 0},
+	}
+
+	rankStrength(teamList,
+		func(team *Team) float64 { return team.SOV },
+		func(team *Team, rank int64, norm float64) {
+			team.SOVRank = rank
+			team.SOVNorm = norm
+		},
+	)
+
+	for id, want := range map[int64]int64{1: 1, 2: 1, 3: 3, 4: 3} {
+		if teamList[id].SOVRank != want {
+			t.Errorf("team %d SOVRank = %d, want %d", id, teamList[id].SOVRank, want)
+		}
+	}
+}
@@ -24,6 +24,8 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		&database.Game{},
 		&database.TeamSeason{},
 		&database.TeamName{},
+		&database.EloHistory{},
+		&database.SeasonSimulation{},
 	); err != nil {
 		t.Fatalf("failed to migrate: %v", err)
 	}
@@ -47,16 +49,16 @@ func seedTestData(t *testing.T, db *gorm.DB) {
 	}
 
 	teamSeasons := []database.TeamSeason{
-		{TeamID: 1, Year: 2023, FBS: 1, Conf: "SEC", Sport: "ncaaf"},
-		{TeamID: 2, Year: 2023, FBS: 1, Conf: "SEC", Sport: "ncaaf"},
-		{TeamID: 3, Year: 2023, FBS: 1, Conf: "Big Ten", Sport: "ncaaf"},
-		{TeamID: 4, Year: 2023, FBS: 1, Conf: "Big Ten", Sport: "ncaaf"},
-		{TeamID: 5, Year: 2023, FBS: 0, Conf: "FCS", Sport: "ncaaf"},
+		{TeamID: 1, Year: 2023, Division: database.DivisionFBS, Conf: "SEC", Sport: "ncaaf"},
+		{TeamID: 2, Year: 2023, Division: database.DivisionFBS, Conf: "SEC", Sport: "ncaaf"},
+		{TeamID: 3, Year: 2023, Division: database.DivisionFBS, Conf: "Big Ten", Sport: "ncaaf"},
+		{TeamID: 4, Year: 2023, Division: database.DivisionFBS, Conf: "Big Ten", Sport: "ncaaf"},
+		{TeamID: 5, Year: 2023, Division: database.DivisionFCS, Conf: "FCS", Sport: "ncaaf"},
 		// Historical team_seasons for 2022
-		{TeamID: 1, Year: 2022, FBS: 1, Conf: "SEC", Sport: "ncaaf"},
-		{TeamID: 2, Year: 2022, FBS: 1, Conf: "SEC", Sport: "ncaaf"},
-		{TeamID: 3, Year: 2022, FBS: 1, Conf: "Big Ten", Sport: "ncaaf"},
-		{TeamID: 4, Year: 2022, FBS: 1, Conf: "Big Ten", Sport: "ncaaf"},
+		{TeamID: 1, Year: 2022, Division: database.DivisionFBS, Conf: "SEC", Sport: "ncaaf"},
+		{TeamID: 2, Year: 2022, Division: database.DivisionFBS, Conf: "SEC", Sport: "ncaaf"},
+		{TeamID: 3, Year: 2022, Division: database.DivisionFBS, Conf: "Big Ten", Sport: "ncaaf"},
+		{TeamID: 4, Year: 2022, Division: database.DivisionFBS, Conf: "Big Ten", Sport: "ncaaf"},
 	}
 	if err := db.Create(&teamSeasons).Error; err != nil {
 		t.Fatalf("seed team_seasons: %v", err)
@@ -0,0 +1,116 @@
+package ranking
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+const (
+	// uncertaintyCoverage is the central share of resampled outcomes reported
+	// as a team's interval and rank range.
+	uncertaintyCoverage = 0.9
+	// maxResampleDraws caps how often a resample that leaves a team without a
+	// game is drawn again.
+	maxResampleDraws = 100
+)
+
+// uncertainty bootstraps the composite ranking. Each resample draws the SRS
+// game set with replacement and reruns SRS, SOS and the final ranking on it,
+// holding records fixed, since a team's wins are not in doubt but how strong
+// they make it look is. Each team's FinalRawLow/High and RankBest/Worst are set
+// to the central uncertaintyCoverage of its resampled outcomes.
+//
+// The generator is seeded from the year and week, so the same games always
+// produce the same ranges.
+func (r *Ranker) uncertainty(teamList TeamList) error {
+	games, err := r.srsGames(teamList)
+	if err != nil {
+		return err
+	}
+	if len(games) == 0 {
+		return nil
+	}
+
+	covered := map[int64]bool{}
+	for _, game := range games {
+		covered[game.HomeID] = true
+		covered[game.AwayID] = true
+	}
+
+	raws := map[int64][]float64{}
+	ranks := map[int64][]int64{}
+	sample := make([]database.Game, len(games))
+	var seasonGames []database.Game
+	//nolint:gosec // the year and week only seed a reproducible resample
+	rng := rand.New(rand.NewPCG(uint64(r.Year), uint64(r.Week)))
+	for range r.Resamples {
+		resampleGames(rng, games, sample, covered)
+
+		replica := TeamList{}
+		for id, team := range teamList {
+			replica[id] = &Team{Division: team.Division, Record: team.Record}
+		}
+
+		r.rateSRS(replica, sample)
+
+		seasonGames = seasonGames[:0]
+		for _, game := range sample {
+			if game.Season == r.Year {
+				seasonGames = append(seasonGames, game)
+			}
+		}
+		if err := rateSOS(replica, seasonGames); err != nil {
+			return err
+		}
+
+		r.finalRanking(replica)
+		if r.unified() {
+			// ranges are within each division, like FinalRank
+			rankDivisions(replica)
+		}
+
+		for id, team := range replica {
+			raws[id] = append(raws[id], team.FinalRaw)
+			ranks[id] = append(ranks[id], team.FinalRank)
+		}
+	}
+
+	tail := (1 - uncertaintyCoverage) / 2
+	for id, team := range teamList {
+		slices.Sort(raws[id])
+		slices.Sort(ranks[id])
+		team.FinalRawLow = percentile(raws[id], tail)
+		team.FinalRawHigh = percentile(raws[id], 1-tail)
+		team.RankBest = percentile(ranks[id], tail)
+		team.RankWorst = percentile(ranks[id], 1-tail)
+	}
+
+	return nil
+}
+
+// resampleGames fills sample with games drawn from games with replacement.
+// A draw that leaves one of the covered teams without a game would give it no
+// rating at all, so it is drawn again, up to maxResampleDraws times.
+func resampleGames(rng *rand.Rand, games []database.Game, sample []database.Game, covered map[int64]bool) {
+	for range maxResampleDraws {
+		seen := make(map[int64]bool, len(covered))
+		for i := range sample {
+			game := games[rng.IntN(len(games))]
+			sample[i] = game
+			seen[game.HomeID] = true
+			seen[game.AwayID] = true
+		}
+		if len(seen) == len(covered) {
+			return
+		}
+	}
+}
+
+// percentile returns the value at fraction p of sorted by nearest rank.
+func percentile[T cmp.Ordered](sorted []T, p float64) T {
+	return sorted[int(math.Round(p*float64(len(sorted)-1)))]
+}
@@ -0,0 +1,81 @@
+package ranking
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateRanking_Uncertainty(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball, Resamples: 200}
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	for id, team := range teamList {
+		if team.RankBest < 1 || team.RankBest > team.RankWorst || team.RankWorst > int64(len(teamList)) {
+			t.Errorf("team %d: rank range %d-%d", id, team.RankBest, team.RankWorst)
+		}
+		if team.FinalRawLow > team.FinalRawHigh {
+			t.Errorf("team %d: interval %f-%f", id, team.FinalRawLow, team.FinalRawHigh)
+		}
+	}
+	// Alpha is unbeaten and ranked first in every resample.
+	if teamList[1].RankBest != 1 || teamList[1].RankWorst != 1 {
+		t.Errorf("Alpha rank range = %d-%d, want 1-1", teamList[1].RankBest, teamList[1].RankWorst)
+	}
+
+	// The point ranking is unchanged by resampling.
+	plain, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	for id, team := range teamList {
+		if team.FinalRaw != plain[id].FinalRaw || team.FinalRank != plain[id].FinalRank {
+			t.Errorf("team %d: FinalRaw/FinalRank = %f/%d, want %f/%d",
+				id, team.FinalRaw, team.FinalRank, plain[id].FinalRaw, plain[id].FinalRank)
+		}
+		if plain[id].RankBest != 0 || plain[id].RankWorst != 0 {
+			t.Errorf("team %d: rank range %d-%d without resampling, want 0-0",
+				id, plain[id].RankBest, plain[id].RankWorst)
+		}
+	}
+
+	// The same games always give the same ranges.
+	again, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball, Resamples: 200}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+	if !reflect.DeepEqual(teamList, again) {
+		t.Error("repeated resampling gave different results")
+	}
+}
+
+func TestCalculateRanking_UncertaintyCompositeOnly(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{DB: db, Year: 2023, Sport: sportFootball, Model: "colley", Resamples: 10}
+	if _, err := r.CalculateRanking(); err == nil {
+		t.Error("CalculateRanking with colley and resamples: want error")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var sorted []int64
+	for i := int64(1); i <= 21; i++ {
+		sorted = append(sorted, i)
+	}
+	if got := percentile(sorted, 0.05); got != 2 {
+		t.Errorf("percentile(0.05) = %d, want 2", got)
+	}
+	if got := percentile(sorted, 0.95); got != 20 {
+		t.Errorf("percentile(0.95) = %d, want 20", got)
+	}
+	if got := percentile(sorted, 0.5); got != 11 {
+		t.Errorf("percentile(0.5) = %d, want 11", got)
+	}
+}
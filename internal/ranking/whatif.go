@@ -0,0 +1,131 @@
+package ranking
+
+import (
+	"slices"
+	"time"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// HypotheticalGame is a result to rank as if it were final. If the two teams
+// have a game stored for the ranking season, the hypothetical result replaces
+// it; otherwise it is ranked as an extra game.
+type HypotheticalGame struct {
+	HomeID    int64
+	AwayID    int64
+	HomeScore int64
+	AwayScore int64
+	Neutral   bool
+}
+
+// overlaySource layers hypothetical games over another game source. The
+// hypothetical games count as played before any cutoff, so an upcoming game
+// can be ranked without moving the ranking week.
+type overlaySource struct {
+	GameSource
+	extra    []database.Game
+	replaced map[int64]bool
+}
+
+func (s overlaySource) games(q gameQuery) ([]database.Game, error) {
+	baseQuery := q
+	baseQuery.limit = 0
+	baseGames, err := s.GameSource.games(baseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var games []database.Game
+	for _, game := range baseGames {
+		if !s.replaced[game.GameID] {
+			games = append(games, game)
+		}
+	}
+
+	anyTime := q
+	anyTime.before = time.Time{}
+	match := anyTime.matcher()
+	for _, game := range s.extra {
+		if match(game) {
+			games = append(games, game)
+		}
+	}
+
+	slices.SortStableFunc(games, func(a, b database.Game) int {
+		return b.StartTime.Compare(a.StartTime)
+	})
+	if q.limit > 0 && len(games) > q.limit {
+		games = games[:q.limit]
+	}
+
+	return games, nil
+}
+
+// overlay wraps the Ranker's game source with its hypothetical games. It runs
+// after setGlobals, once the ranking season and cutoff are known. The games
+// replaced are looked up in DB whatever the source.
+func (r *Ranker) overlay() error {
+	if len(r.Hypothetical) == 0 {
+		return nil
+	}
+
+	overlay := overlaySource{GameSource: r.gameSource(), replaced: map[int64]bool{}}
+
+	sport := r.sportFilter()
+	var nextID int64 = -1
+	for _, hypothetical := range r.Hypothetical {
+		var stored []database.Game
+		if err := r.DB.
+			Where(
+				"sport = ? and season = ? and ((home_id = ? and away_id = ?) or (home_id = ? and away_id = ?))",
+				sport, r.Year,
+				hypothetical.HomeID, hypothetical.AwayID, hypothetical.AwayID, hypothetical.HomeID,
+			).
+			Find(&stored).Error; err != nil {
+			return err
+		}
+
+		// prefer the next game still to be played, then the latest one played
+		slices.SortStableFunc(stored, func(a, b database.Game) int {
+			aFinal, bFinal := a.Status == database.GameFinal, b.Status == database.GameFinal
+			switch {
+			case aFinal && !bFinal:
+				return 1
+			case !aFinal && bFinal:
+				return -1
+			case aFinal:
+				return b.StartTime.Compare(a.StartTime)
+			default:
+				return a.StartTime.Compare(b.StartTime)
+			}
+		})
+
+		game := database.Game{
+			GameID:    nextID,
+			Season:    r.Year,
+			Week:      r.Week,
+			StartTime: r.startTime,
+			Sport:     sport,
+			Neutral:   hypothetical.Neutral,
+		}
+		for _, candidate := range stored {
+			if !overlay.replaced[candidate.GameID] {
+				game = candidate
+				overlay.replaced[candidate.GameID] = true
+				break
+			}
+		}
+		if game.GameID == nextID {
+			nextID--
+		}
+
+		game.HomeID, game.AwayID = hypothetical.HomeID, hypothetical.AwayID
+		game.HomeScore, game.AwayScore = hypothetical.HomeScore, hypothetical.AwayScore
+		game.Neutral = game.Neutral || hypothetical.Neutral
+		game.Status = database.GameFinal
+		overlay.extra = append(overlay.extra, game)
+	}
+
+	r.source = overlay
+	return nil
+}
@@ -0,0 +1,141 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+func gamesPlayed(team *Team) int64 {
+	return team.Record.Wins + team.Record.Losses + team.Record.Ties
+}
+
+func TestWhatIf_ExtraGame(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	before, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	// Delta and Epsilon haven't played each other.
+	r := &Ranker{
+		DB: db, Year: 2023, Sport: sportFootball,
+		Hypothetical: []HypotheticalGame{{HomeID: 4, AwayID: 5, HomeScore: 30, AwayScore: 0}},
+	}
+	after, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking with hypothetical: %v", err)
+	}
+
+	if got, want := after[4].Record.Wins, before[4].Record.Wins+1; got != want {
+		t.Errorf("Delta wins = %d, want %d", got, want)
+	}
+	if after[4].FinalRaw <= before[4].FinalRaw {
+		t.Errorf("Delta FinalRaw = %f, want above %f", after[4].FinalRaw, before[4].FinalRaw)
+	}
+	if after[1].Record != before[1].Record {
+		t.Errorf("Alpha record = %v, want unchanged %v", after[1].Record, before[1].Record)
+	}
+
+	// Nothing is written to the database.
+	var count int64
+	db.Model(&database.Game{}).Count(&count)
+	if count != 12 {
+		t.Errorf("game count = %d, want 12", count)
+	}
+}
+
+func TestWhatIf_ReplacesScheduledGame(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	scheduled := database.Game{
+		GameID: 1011, Season: 2023, Week: 6, HomeID: 1, AwayID: 4, Sport: "ncaaf",
+		Status: database.GameScheduled, StartTime: time.Date(2023, 10, 10, 19, 0, 0, 0, time.UTC),
+	}
+	if err := db.Create(&scheduled).Error; err != nil {
+		t.Fatalf("seed scheduled game: %v", err)
+	}
+
+	before, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	r := &Ranker{
+		DB: db, Year: 2023, Sport: sportFootball,
+		Hypothetical: []HypotheticalGame{{HomeID: 1, AwayID: 4, HomeScore: 10, AwayScore: 20}},
+	}
+	after, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking with hypothetical: %v", err)
+	}
+
+	// The upcoming game counts without moving the ranking week, and the
+	// week 3 meeting is still counted as played.
+	if r.Week != 6 {
+		t.Errorf("Week = %d, want 6", r.Week)
+	}
+	if got, want := after[1].Record.Losses, before[1].Record.Losses+1; got != want {
+		t.Errorf("Alpha losses = %d, want %d", got, want)
+	}
+	if got, want := gamesPlayed(after[4]), gamesPlayed(before[4])+1; got != want {
+		t.Errorf("Delta games = %d, want %d", got, want)
+	}
+}
+
+func TestWhatIf_ReplacesFinalGame(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	before, err := (&Ranker{DB: db, Year: 2023, Sport: sportFootball}).CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking: %v", err)
+	}
+
+	// Delta wins the week 3 game Alpha won 42-7.
+	r := &Ranker{
+		DB: db, Year: 2023, Sport: sportFootball,
+		Hypothetical: []HypotheticalGame{{HomeID: 4, AwayID: 1, HomeScore: 20, AwayScore: 10}},
+	}
+	after, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("CalculateRanking with hypothetical: %v", err)
+	}
+
+	if got, want := after[1].Record.Losses, before[1].Record.Losses+1; got != want {
+		t.Errorf("Alpha losses = %d, want %d", got, want)
+	}
+	if got, want := gamesPlayed(after[1]), gamesPlayed(before[1]); got != want {
+		t.Errorf("Alpha games = %d, want %d", got, want)
+	}
+	if got, want := after[4].Record.Wins, before[4].Record.Wins+1; got != want {
+		t.Errorf("Delta wins = %d, want %d", got, want)
+	}
+
+	// A second run applies the hypothetical once, not on top of itself.
+	again, err := r.CalculateRanking()
+	if err != nil {
+		t.Fatalf("second CalculateRanking: %v", err)
+	}
+	if again[1].Record != after[1].Record || again[1].FinalRaw != after[1].FinalRaw {
+		t.Errorf("second run Alpha = %v %f, want %v %f",
+			again[1].Record, again[1].FinalRaw, after[1].Record, after[1].FinalRaw)
+	}
+}
+
+func TestWhatIf_Elo(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(t, db)
+
+	r := &Ranker{
+		DB: db, Year: 2023, Sport: sportFootball, Model: "elo",
+		Hypothetical: []HypotheticalGame{{HomeID: 4, AwayID: 5, HomeScore: 30, AwayScore: 0}},
+	}
+	if _, err := r.CalculateRanking(); err == nil {
+		t.Error("elo with hypothetical games: want error")
+	}
+}
@@ -0,0 +1,310 @@
+// Package simulate plays out the rest of a season many times from current
+// ratings to estimate each team's final wins, conference title and ranking
+// odds.
+package simulate
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"gorm.io/gorm"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/predict"
+	"github.com/robby-barton/stats-go/internal/ranking"
+)
+
+// Simulator simulates the remaining regular season of one division.
+type Simulator struct {
+	DB       *gorm.DB
+	Sport    string
+	Year     int64             // 0 selects the current season
+	Week     int64             // simulate from the start of this week; 0 selects the next week
+	Division database.Division // football division; empty selects FBS
+	Runs     int
+	TopN     int
+	Seed     int64
+}
+
+// record is a team's wins, losses and ties overall and in conference play.
+type record struct {
+	wins, losses, ties             int64
+	confWins, confLosses, confTies int64
+}
+
+func (r *record) add(won bool, confGame bool) {
+	if won {
+		r.wins++
+		if confGame {
+			r.confWins++
+		}
+	} else {
+		r.losses++
+		if confGame {
+			r.confLosses++
+		}
+	}
+}
+
+func (r *record) confGames() int64 {
+	return r.confWins + r.confLosses + r.confTies
+}
+
+func (r *record) confPct() float64 {
+	return (float64(r.confWins) + 0.5*float64(r.confTies)) / float64(r.confGames())
+}
+
+// matchup is a remaining game between two teams of the division, by index.
+type matchup struct {
+	home, away  int
+	homeWinProb float64
+	confGame    bool
+}
+
+// Run simulates the rest of the season and returns one row per team of the
+// division. Remaining games against teams outside the division are left
+// out, as there is no rating to predict them from.
+func (s *Simulator) Run() ([]database.SeasonSimulation, error) {
+	r := &ranking.Ranker{DB: s.DB, Year: s.Year, Week: s.Week, Division: s.Division, Sport: s.Sport}
+	teamList, err := r.CalculateRanking()
+	if err != nil {
+		return nil, err
+	}
+	if len(teamList) == 0 {
+		return nil, nil
+	}
+
+	predictor, err := predict.NewPredictor(&ranking.Ranker{
+		DB: s.DB, Year: r.Year, Week: r.Week, Division: s.Division, Sport: s.Sport,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(teamList))
+	for id := range teamList {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	index := map[int64]int{}
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	current, err := s.currentRecords(r, teamList, ids, index)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := s.remainingGames(r, predictor, index)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWins := make([]int64, len(ids))
+	for i := range ids {
+		maxWins[i] = current[i].wins
+	}
+	for _, game := range remaining {
+		maxWins[game.home]++
+		maxWins[game.away]++
+	}
+
+	winCounts := make([][]int64, len(ids))
+	for i := range ids {
+		winCounts[i] = make([]int64, maxWins[i]+1)
+	}
+	confTitles := make([]float64, len(ids))
+	topN := make([]int64, len(ids))
+
+	seed := uint64(s.Seed) //nolint:gosec // the seed's bits are reused, not its value
+	rng := rand.New(rand.NewPCG(seed, seed))
+	records := make([]record, len(ids))
+	order := make([]int, len(ids))
+	raws := make([]float64, len(ids))
+	for range s.Runs {
+		copy(records, current)
+		for _, game := range remaining {
+			homeWon := rng.Float64() < game.homeWinProb
+			records[game.home].add(homeWon, game.confGame)
+			records[game.away].add(!homeWon, game.confGame)
+		}
+
+		for i := range ids {
+			winCounts[i][records[i].wins]++
+		}
+
+		for _, winners := range conferenceWinners(teamList, ids, records) {
+			for _, i := range winners {
+				confTitles[i] += 1 / float64(len(winners))
+			}
+		}
+
+		for i, id := range ids {
+			order[i] = i
+			raws[i] = r.ProjectFinalRaw(teamList[id], records[i].wins, records[i].losses, records[i].ties)
+		}
+		sort.SliceStable(order, func(a, b int) bool { return raws[order[a]] > raws[order[b]] })
+		for rank, i := range order {
+			if rank >= s.TopN {
+				break
+			}
+			topN[i]++
+		}
+	}
+
+	runs := float64(s.Runs)
+	results := make([]database.SeasonSimulation, 0, len(ids))
+	for i, id := range ids {
+		team := teamList[id]
+
+		winDist := make([]float64, len(winCounts[i]))
+		var meanWins float64
+		for wins, count := range winCounts[i] {
+			winDist[wins] = float64(count) / runs
+			meanWins += float64(wins) * winDist[wins]
+		}
+
+		results = append(results, database.SeasonSimulation{
+			TeamID:      id,
+			Year:        r.Year,
+			Week:        r.Week,
+			Sport:       s.Sport,
+			Name:        team.Name,
+			Conf:        team.Conf,
+			Division:    team.Division,
+			Fbs:         team.Division.IsFBS(),
+			Runs:        int64(s.Runs),
+			Seed:        s.Seed,
+			MeanWins:    meanWins,
+			WinDist:     winDist,
+			ConfWinProb: confTitles[i] / runs,
+			TopN:        int64(s.TopN),
+			TopNProb:    float64(topN[i]) / runs,
+		})
+	}
+
+	return results, nil
+}
+
+// currentRecords returns each team's record so far: overall from the
+// ranking, and in conference play from this season's final conference games
+// before the simulated week.
+func (s *Simulator) currentRecords(
+	r *ranking.Ranker,
+	teamList ranking.TeamList,
+	ids []int64,
+	index map[int64]int,
+) ([]record, error) {
+	records := make([]record, len(ids))
+	for i, id := range ids {
+		team := teamList[id]
+		records[i].wins = team.Record.Wins
+		records[i].losses = team.Record.Losses
+		records[i].ties = team.Record.Ties
+	}
+
+	var games []database.Game
+	if err := s.DB.
+		Where("sport = ? and status = ? and season = ? and week < ? and postseason = 0 and conf_game = ?",
+			s.Sport, database.GameFinal, r.Year, r.Week, true).
+		Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	for _, game := range games {
+		home, homeOK := index[game.HomeID]
+		away, awayOK := index[game.AwayID]
+		if !homeOK || !awayOK {
+			continue
+		}
+		switch {
+		case game.HomeScore > game.AwayScore:
+			records[home].confWins++
+			records[away].confLosses++
+		case game.AwayScore > game.HomeScore:
+			records[home].confLosses++
+			records[away].confWins++
+		default:
+			records[home].confTies++
+			records[away].confTies++
+		}
+	}
+
+	return records, nil
+}
+
+// remainingGames returns this season's regular-season games left to play
+// between two rated teams, in the order they are played. For an explicit week
+// these are the games from that week on, and games that are already final
+// count as unplayed, so a past week can be simulated as it looked at the
+// time. Otherwise they are every game not yet final, including those of a
+// partially played week.
+func (s *Simulator) remainingGames(
+	r *ranking.Ranker,
+	predictor *predict.Predictor,
+	index map[int64]int,
+) ([]matchup, error) {
+	query := s.DB.Where(
+		"sport = ? and season = ? and postseason = 0 and status not in (?)",
+		s.Sport, r.Year, []string{database.GamePostponed, database.GameCanceled},
+	)
+	if s.Week > 0 {
+		query = query.Where("week >= ?", r.Week)
+	} else {
+		query = query.Where("status <> ?", database.GameFinal)
+	}
+
+	var games []database.Game
+	if err := query.
+		Order("start_time asc, game_id asc").
+		Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	var remaining []matchup
+	for _, game := range games {
+		home, homeOK := index[game.HomeID]
+		away, awayOK := index[game.AwayID]
+		if !homeOK || !awayOK {
+			continue
+		}
+		prediction, err := predictor.Game(game.HomeID, game.AwayID, game.Neutral)
+		if err != nil {
+			// played no games against the division yet
+			continue
+		}
+		remaining = append(remaining, matchup{
+			home:        home,
+			away:        away,
+			homeWinProb: prediction.HomeWinProb,
+			confGame:    game.ConfGame,
+		})
+	}
+
+	return remaining, nil
+}
+
+// conferenceWinners returns, for each conference, the indexes of the teams
+// with the best conference winning percentage. Teams without a conference game
+// (independents) can't win one.
+func conferenceWinners(teamList ranking.TeamList, ids []int64, records []record) map[string][]int {
+	best := map[string]float64{}
+	winners := map[string][]int{}
+	for i, id := range ids {
+		if records[i].confGames() == 0 {
+			continue
+		}
+		conf := teamList[id].Conf
+		pct := records[i].confPct()
+		leader, ok := best[conf]
+		switch {
+		case !ok || pct > leader:
+			best[conf] = pct
+			winners[conf] = []int{i}
+		case pct == leader:
+			winners[conf] = append(winners[conf], i)
+		}
+	}
+	return winners
+}
@@ -0,0 +1,260 @@
+package simulate
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/robby-barton/stats-go/internal/database"
+)
+
+// setupSeason seeds two three-team conferences with three played weeks and
+// two scheduled ones.
+func setupSeason(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&database.Game{}, &database.TeamSeason{}, &database.TeamName{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	var names []database.TeamName
+	var seasons []database.TeamSeason
+	for id := int64(1); id <= 6; id++ {
+		conf := "East"
+		if id > 3 {
+			conf = "West"
+		}
+		names = append(names, database.TeamName{TeamID: id, Name: string(rune('A' + id - 1)), Sport: "ncaaf"})
+		seasons = append(seasons, database.TeamSeason{
+			TeamID: id, Year: 2023, Division: database.DivisionFBS, Conf: conf, Sport: "ncaaf",
+		})
+	}
+	if err := db.Create(&names).Error; err != nil {
+		t.Fatalf("seed team_names: %v", err)
+	}
+	if err := db.Create(&seasons).Error; err != nil {
+		t.Fatalf("seed team_seasons: %v", err)
+	}
+
+	type matchup struct {
+		week, home, away, homeScore, awayScore int64
+		conf                                   bool
+	}
+	matchups := []matchup{
+		{1, 1, 2, 31, 10, true},
+		{1, 4, 5, 24, 21, true},
+		{1, 3, 6, 17, 20, false},
+		{2, 2, 3, 27, 13, true},
+		{2, 5, 6, 35, 14, true},
+		{2, 1, 4, 21, 24, false},
+		{3, 3, 1, 10, 28, true},
+		{3, 6, 4, 20, 16, true},
+		{3, 2, 5, 20, 17, false},
+		{4, 1, 5, 0, 0, false},
+		{4, 6, 3, 0, 0, false},
+		{4, 4, 2, 0, 0, false},
+		{5, 5, 4, 0, 0, true},
+		{5, 3, 2, 0, 0, true},
+		{5, 6, 1, 0, 0, false},
+	}
+	base := time.Date(2023, 9, 2, 19, 0, 0, 0, time.UTC)
+	var games []database.Game
+	for i, m := range matchups {
+		status := database.GameFinal
+		if m.week >= 4 {
+			status = database.GameScheduled
+		}
+		games = append(games, database.Game{
+			GameID: int64(i + 1), Season: 2023, Week: m.week, HomeID: m.home, AwayID: m.away,
+			HomeScore: m.homeScore, AwayScore: m.awayScore, ConfGame: m.conf, Status: status, Sport: "ncaaf",
+			StartTime: base.Add(time.Duration(m.week-1) * 7 * 24 * time.Hour).Add(time.Duration(i) * time.Hour),
+		})
+	}
+	// Canceled games are never played.
+	games = append(games, database.Game{
+		GameID: 99, Season: 2023, Week: 5, HomeID: 2, AwayID: 1, Status: database.GameCanceled, Sport: "ncaaf",
+		StartTime: base.Add(28 * 24 * time.Hour),
+	})
+	if err := db.Create(&games).Error; err != nil {
+		t.Fatalf("seed games: %v", err)
+	}
+
+	return db
+}
+
+func TestRun(t *testing.T) {
+	db := setupSeason(t)
+
+	s := Simulator{DB: db, Sport: "ncaaf", Year: 2023, Week: 4, Runs: 2000, TopN: 2, Seed: 7}
+	results, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(results) != 6 {
+		t.Fatalf("len(results) = %d, want 6", len(results))
+	}
+
+	// Every team has two games left; no game is tied.
+	currentWins := map[int64]int{1: 2, 2: 2, 3: 0, 4: 2, 5: 1, 6: 2}
+	var totalWins float64
+	confTitles := map[string]float64{}
+	var topN float64
+	for _, result := range results {
+		if result.Year != 2023 || result.Week != 4 || result.Runs != 2000 || result.Seed != 7 ||
+			result.Division != database.DivisionFBS {
+			t.Errorf("team %d: unexpected metadata %+v", result.TeamID, result)
+		}
+
+		var dist float64
+		for wins, p := range result.WinDist {
+			if p > 0 && (wins < currentWins[result.TeamID] || wins > currentWins[result.TeamID]+2) {
+				t.Errorf("team %d: %d wins has probability %f", result.TeamID, wins, p)
+			}
+			dist += p
+		}
+		if math.Abs(dist-1) > 1e-9 {
+			t.Errorf("team %d: win distribution sums to %f, want 1", result.TeamID, dist)
+		}
+
+		totalWins += result.MeanWins
+		confTitles[result.Conf] += result.ConfWinProb
+		topN += result.TopNProb
+	}
+
+	// 9 played and 6 remaining games each produce one win.
+	if math.Abs(totalWins-15) > 1e-9 {
+		t.Errorf("total mean wins = %f, want 15", totalWins)
+	}
+	for conf, p := range confTitles {
+		if math.Abs(p-1) > 1e-9 {
+			t.Errorf("%s title probabilities sum to %f, want 1", conf, p)
+		}
+	}
+	if math.Abs(topN-2) > 1e-9 {
+		t.Errorf("top-2 probabilities sum to %f, want 2", topN)
+	}
+
+	// Exact output under the seed.
+	team4 := results[3]
+	if team4.TeamID != 4 {
+		t.Fatalf("results[3].TeamID = %d, want 4", team4.TeamID)
+	}
+	wantDist := []float64{0, 0, 0.175, 0.522, 0.303}
+	if !reflect.DeepEqual(team4.WinDist, wantDist) {
+		t.Errorf("team 4 WinDist = %v, want %v", team4.WinDist, wantDist)
+	}
+	if math.Abs(team4.MeanWins-3.128) > 1e-9 {
+		t.Errorf("team 4 MeanWins = %f, want 3.128", team4.MeanWins)
+	}
+	if math.Abs(team4.ConfWinProb-0.4525) > 1e-9 {
+		t.Errorf("team 4 ConfWinProb = %f, want 0.4525", team4.ConfWinProb)
+	}
+	if team4.TopNProb != 1 {
+		t.Errorf("team 4 TopNProb = %f, want 1", team4.TopNProb)
+	}
+}
+
+func TestRun_Deterministic(t *testing.T) {
+	db := setupSeason(t)
+
+	s := Simulator{DB: db, Sport: "ncaaf", Year: 2023, Week: 4, Runs: 500, TopN: 2, Seed: 42}
+	first, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	second, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed gave different results:\n%+v\n%+v", first, second)
+	}
+
+	s.Seed = 43
+	other, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if reflect.DeepEqual(first, other) {
+		t.Error("different seeds gave identical results")
+	}
+}
+
+func TestRun_NoRemainingGames(t *testing.T) {
+	db := setupSeason(t)
+	if err := db.Where("status <> ?", database.GameFinal).Delete(&database.Game{}).Error; err != nil {
+		t.Fatalf("delete scheduled games: %v", err)
+	}
+
+	s := Simulator{DB: db, Sport: "ncaaf", Year: 2023, Runs: 100, TopN: 2, Seed: 1}
+	results, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, result := range results {
+		if result.Week != 4 {
+			t.Errorf("team %d: Week = %d, want 4", result.TeamID, result.Week)
+		}
+		// With nothing left to play, the current record is certain.
+		if got := result.WinDist[len(result.WinDist)-1]; got != 1 {
+			t.Errorf("team %d: P(%d wins) = %f, want 1", result.TeamID, len(result.WinDist)-1, got)
+		}
+	}
+
+	// A is 2-0 in the East; the West is a three-way tie at 1-1.
+	wantConf := map[int64]float64{1: 1, 2: 0, 3: 0, 4: 1.0 / 3, 5: 1.0 / 3, 6: 1.0 / 3}
+	for _, result := range results {
+		if math.Abs(result.ConfWinProb-wantConf[result.TeamID]) > 1e-9 {
+			t.Errorf("team %d: ConfWinProb = %f, want %f", result.TeamID, result.ConfWinProb, wantConf[result.TeamID])
+		}
+	}
+}
+
+func TestRun_PartiallyPlayedWeek(t *testing.T) {
+	db := setupSeason(t)
+	// A beats E in week 4; the rest of the week is still to play.
+	if err := db.Model(&database.Game{}).Where("game_id = ?", 10).
+		Updates(map[string]any{"status": database.GameFinal, "home_score": 27, "away_score": 20}).Error; err != nil {
+		t.Fatalf("finish game: %v", err)
+	}
+
+	s := Simulator{DB: db, Sport: "ncaaf", Year: 2023, Runs: 500, TopN: 2, Seed: 7}
+	results, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(results) != 6 {
+		t.Fatalf("len(results) = %d, want 6", len(results))
+	}
+
+	// A and E have one game left, everyone else still has two.
+	currentWins := map[int64]int{1: 3, 2: 2, 3: 0, 4: 2, 5: 1, 6: 2}
+	gamesLeft := map[int64]int{1: 1, 2: 2, 3: 2, 4: 2, 5: 1, 6: 2}
+	var totalWins float64
+	for _, result := range results {
+		if result.Week != 5 {
+			t.Errorf("team %d: Week = %d, want 5", result.TeamID, result.Week)
+		}
+		if got, want := len(result.WinDist), currentWins[result.TeamID]+gamesLeft[result.TeamID]+1; got != want {
+			t.Errorf("team %d: len(WinDist) = %d, want %d", result.TeamID, got, want)
+		}
+		totalWins += result.MeanWins
+	}
+
+	// 10 played and 5 remaining games each produce one win.
+	if math.Abs(totalWins-15) > 1e-9 {
+		t.Errorf("total mean wins = %f, want 15", totalWins)
+	}
+}
@@ -31,12 +31,22 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("failed to open sqlite: %v", err)
 	}
+	// each connection to :memory: is a separate database, so concurrent
+	// rankings must share one
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sqlite handle: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
 
 	if err := db.AutoMigrate(
 		&database.Game{},
 		&database.TeamSeason{},
 		&database.TeamName{},
 		&database.TeamWeekResult{},
+		&database.ConferenceWeekResult{},
+		&database.EloHistory{},
+		&database.SeasonSimulation{},
 		&database.TeamGameStats{},
 		&database.PassingStats{},
 		&database.RushingStats{},
@@ -92,16 +102,16 @@ func fixtureScheduleResponse() espn.GameScheduleESPN {
 			Schedule: map[string]espn.Day{
 				"2023-09-02": {
 					Games: []espn.Game{
-						newFinalGame(fixtureGameID1, 1, 100, 28, 2, 100, 14),
-						newFinalGame(fixtureGameID2, 3, 200, 21, 4, 200, 10),
-						newInProgressGame(fixtureGameID3, 5, 100, 7, 6, 200, 3),
+						newFinalGame(fixtureGameID1, "2023-09-02T23:00Z", 1, 100, 28, 2, 100, 14),
+						newFinalGame(fixtureGameID2, "2023-09-02T23:00Z", 3, 200, 21, 4, 200, 10),
+						newInProgressGame(fixtureGameID3, "2023-09-02T23:00Z", 5, 100, 7, 6, 200, 3),
 					},
 				},
 				"2023-09-09": {
 					Games: []espn.Game{
-						newFinalGame(fixtureGameID4, 1, 100, 35, 3, 200, 17),
-						newFinalGame(fixtureGameID5, 2, 100, 24, 4, 200, 21),
-						newInProgressGame(fixtureGameID6, 5, 100, 14, 6, 200, 10),
+						newFinalGame(fixtureGameID4, "2023-09-09T23:00Z", 1, 100, 35, 3, 200, 17),
+						newFinalGame(fixtureGameID5, "2023-09-09T23:00Z", 2, 100, 24, 4, 200, 21),
+						newInProgressGame(fixtureGameID6, "2023-09-09T23:00Z", 5, 100, 14, 6, 200, 10),
 					},
 				},
 			},
@@ -137,9 +147,12 @@ func fixtureScheduleResponse() espn.GameScheduleESPN {
 	}
 }
 
-func newFinalGame(id int64, homeID, homeConf, homeScore, awayID, awayConf, awayScore int64) espn.Game {
+func newFinalGame(
+	id int64, date string, homeID, homeConf, homeScore, awayID, awayConf, awayScore int64,
+) espn.Game {
 	return espn.Game{
-		ID: id,
+		ID:   id,
+		Date: date,
 		Status: espn.Status{StatusType: espn.StatusType{
 			Name: "STATUS_FINAL", Completed: true,
 		}},
@@ -152,9 +165,12 @@ func newFinalGame(id int64, homeID, homeConf, homeScore, awayID, awayConf, awayS
 	}
 }
 
-func newInProgressGame(id int64, homeID, homeConf, homeScore, awayID, awayConf, awayScore int64) espn.Game {
+func newInProgressGame(
+	id int64, date string, homeID, homeConf, homeScore, awayID, awayConf, awayScore int64,
+) espn.Game {
 	return espn.Game{
-		ID: id,
+		ID:   id,
+		Date: date,
 		Status: espn.Status{StatusType: espn.StatusType{
 			Name: "STATUS_IN_PROGRESS", Completed: false,
 		}},
@@ -433,7 +449,6 @@ func newTestUpdater(t *testing.T, scoreOverride map[int64][2]int64) *Updater {
 		MaxRetries:     2,
 		InitialBackoff: 10 * time.Millisecond,
 		RequestTimeout: 5 * time.Second,
-		RateLimit:      0,
 		Sport:          espn.CollegeFootball,
 	}}
 
@@ -465,12 +480,12 @@ func seedTeamsAndSeasons(t *testing.T, db *gorm.DB) {
 	}
 
 	teamSeasons := []database.TeamSeason{
-		{TeamID: 1, Year: 2023, FBS: 1, Conf: "SEC", Sport: "ncaaf"},
-		{TeamID: 2, Year: 2023, FBS: 1, Conf: "SEC", Sport: "ncaaf"},
-		{TeamID: 3, Year: 2023, FBS: 1, Conf: "Big Ten", Sport: "ncaaf"},
-		{TeamID: 4, Year: 2023, FBS: 1, Conf: "Big Ten", Sport: "ncaaf"},
-		{TeamID: 5, Year: 2023, FBS: 0, Conf: "MVFC", Sport: "ncaaf"},
-		{TeamID: 6, Year: 2023, FBS: 0, Conf: "MVFC", Sport: "ncaaf"},
+		{TeamID: 1, Year: 2023, Division: database.DivisionFBS, Conf: "SEC", Sport: "ncaaf"},
+		{TeamID: 2, Year: 2023, Division: database.DivisionFBS, Conf: "SEC", Sport: "ncaaf"},
+		{TeamID: 3, Year: 2023, Division: database.DivisionFBS, Conf: "Big Ten", Sport: "ncaaf"},
+		{TeamID: 4, Year: 2023, Division: database.DivisionFBS, Conf: "Big Ten", Sport: "ncaaf"},
+		{TeamID: 5, Year: 2023, Division: database.DivisionFCS, Conf: "MVFC", Sport: "ncaaf"},
+		{TeamID: 6, Year: 2023, Division: database.DivisionFCS, Conf: "MVFC", Sport: "ncaaf"},
 	}
 	if err := db.Create(&teamSeasons).Error; err != nil {
 		t.Fatalf("seed team_seasons: %v", err)
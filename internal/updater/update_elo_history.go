@@ -0,0 +1,40 @@
+package updater
+
+import (
+	"time"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/ranking"
+)
+
+// updateEloHistory applies newly stored games to the Elo rating history. Games
+// are replayed from the earliest of them onward so a late-arriving or corrected
+// game still leaves every later rating consistent.
+func (u *Updater) updateEloHistory(gameIDs []int64) error {
+	if len(gameIDs) == 0 {
+		return nil
+	}
+
+	var earliest database.Game
+	if err := u.DB.
+		Where("sport = ? and game_id in (?)", u.sportDB(), gameIDs).
+		Order("start_time asc").
+		First(&earliest).Error; err != nil {
+		return err
+	}
+
+	ranker := ranking.Ranker{DB: u.DB, Sport: u.sportDB()}
+	applied, err := ranker.UpdateEloHistory(earliest.StartTime)
+	if err != nil {
+		return err
+	}
+	u.Logger.Infof("applied %d games to Elo history", applied)
+
+	return nil
+}
+
+// RebuildEloHistory recomputes the Elo rating history from every stored game.
+func (u *Updater) RebuildEloHistory() (int, error) {
+	ranker := ranking.Ranker{DB: u.DB, Sport: u.sportDB()}
+	return ranker.UpdateEloHistory(time.Time{})
+}
@@ -1,8 +1,10 @@
 package updater
 
 import (
+	"context"
 	"errors"
-	"time"
+	"io/fs"
+	"sync"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,8 +16,8 @@ import (
 
 func (u *Updater) checkGames(games []espn.Game) ([]espn.Game, error) {
 	gameIDs := []int64{}
-	for _, game := range games {
-		gameIDs = append(gameIDs, game.ID)
+	for _, g := range games {
+		gameIDs = append(gameIDs, g.ID)
 	}
 	var existing []database.Game
 	if err := u.DB.Where("game_id in ? and sport = ?", gameIDs, u.sportDB()).Find(&existing).Error; err != nil {
@@ -28,19 +30,22 @@ func (u *Updater) checkGames(games []espn.Game) ([]espn.Game, error) {
 	}
 
 	var newGames []espn.Game
-	for _, game := range games {
-		existingGame, ok := existsMap[game.ID]
+	for _, g := range games {
+		existingGame, ok := existsMap[g.ID]
 		if !ok {
-			newGames = append(newGames, game)
+			newGames = append(newGames, g)
 		} else {
-			teams := game.Competitions[0]
+			teams := g.Competitions[0]
 			home := teams.Competitors[0]
 			away := teams.Competitors[1]
 			if home.HomeAway == "away" {
 				home, away = away, home
 			}
-			if existingGame.HomeScore != home.Score || existingGame.AwayScore != away.Score {
-				newGames = append(newGames, game)
+			if existingGame.HomeScore != home.Score || existingGame.AwayScore != away.Score ||
+				existingGame.Status != game.ParseStatus(g.Status.StatusType) ||
+				!existingGame.StartTime.Equal(game.ParseStartTime(g.Date)) ||
+				existingGame.Venue != teams.Venue.FullName {
+				newGames = append(newGames, g)
 			}
 		}
 	}
@@ -172,36 +177,119 @@ func (u *Updater) insertGameInfo(game *game.ParsedGameInfo) error {
 	})
 }
 
-const gamesBatchSize = 100
+// gamesLogInterval is how often processGames reports its progress.
+const gamesLogInterval = 100
 
-func (u *Updater) processGames(games []espn.Game) ([]int64, error) {
-	var allGameIDs []int64
+// fetchedGame is a box score fetched and parsed by a processGames worker.
+type fetchedGame struct {
+	id    int64
+	stats *game.ParsedGameInfo
+	err   error
+}
 
-	for start := 0; start < len(games); start += gamesBatchSize {
-		end := min(start+gamesBatchSize, len(games))
-		batch := games[start:end]
+// processGames stores games that are not final from their schedule entries and
+// fetches the box score of each final game. It returns the IDs of the final
+// games stored, including those stored before ctx was canceled.
+//
+// Box scores are fetched and parsed by a pool of workers, paced by the ESPN
+// client's shared rate limiter, and stored one at a time as they arrive.
+func (u *Updater) processGames(ctx context.Context, games []espn.Game) ([]int64, error) {
+	var finalGames []espn.Game
+	var scheduled []database.Game
+	for _, g := range games {
+		if g.Final() {
+			finalGames = append(finalGames, g)
+		} else {
+			scheduled = append(scheduled, game.ParseScheduledGame(u.ESPN, g))
+		}
+	}
 
-		for _, g := range batch {
-			stats, err := game.GetSingleGame(u.ESPN, g.ID)
-			if err != nil {
-				u.Logger.Warnf("skipping game %d: %v", g.ID, err)
-				continue
+	if err := u.insertScheduledGames(scheduled); err != nil {
+		return nil, err
+	}
+	games = finalGames
+
+	// canceled on the first failed insert so the workers stop fetching
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int64)
+	results := make(chan fetchedGame)
+	var wg sync.WaitGroup
+	for range min(u.fetchWorkers(), len(games)) {
+		wg.Go(func() {
+			for id := range jobs {
+				stats, err := game.GetSingleGame(fetchCtx, u.ESPN, id)
+				results <- fetchedGame{id: id, stats: stats, err: err}
 			}
-			if err := u.insertGameInfo(stats); err != nil {
-				return allGameIDs, err
+		})
+	}
+	go func() {
+		defer close(jobs)
+		for _, g := range games {
+			select {
+			case jobs <- g.ID:
+			case <-fetchCtx.Done():
+				return
 			}
-			allGameIDs = append(allGameIDs, stats.GameInfo.GameID)
-			time.Sleep(u.ESPN.RateLimitDuration())
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var allGameIDs []int64
+	var insertErr error
+	var processed int
+	for res := range results {
+		processed++
+		if processed%gamesLogInterval == 0 || processed == len(games) {
+			u.Logger.Infof("processed %d/%d games", processed, len(games))
 		}
 
-		u.Logger.Infof("processed %d/%d games", end, len(games))
+		switch {
+		case insertErr != nil || fetchCtx.Err() != nil:
+			// draining the workers
+		case res.err != nil:
+			u.Logger.Warnf("skipping game %d: %v", res.id, res.err)
+		default:
+			if err := u.insertGameInfo(res.stats); err != nil {
+				insertErr = err
+				cancel()
+				continue
+			}
+			allGameIDs = append(allGameIDs, res.stats.GameInfo.GameID)
+		}
 	}
 
-	return allGameIDs, nil
+	if insertErr != nil {
+		return allGameIDs, insertErr
+	}
+	return allGameIDs, ctx.Err()
 }
 
-func (u *Updater) UpdateCurrentWeek() ([]int64, error) {
-	games, err := game.GetCurrentWeekGames(u.ESPN)
+// insertScheduledGames upserts games that have not finished. Their box scores
+// are fetched, and the rows replaced, once they are final.
+func (u *Updater) insertScheduledGames(games []database.Game) error {
+	if len(games) == 0 {
+		return nil
+	}
+
+	if err := u.DB.
+		Clauses(clause.OnConflict{
+			UpdateAll: true, // upsert
+		}).
+		CreateInBatches(games, 1000).Error; err != nil {
+		return err
+	}
+	u.Logger.Infof("stored %d games that are not final", len(games))
+
+	return nil
+}
+
+func (u *Updater) UpdateCurrentWeek(ctx context.Context) ([]int64, error) {
+	games, err := game.GetCurrentWeekGames(ctx, u.ESPN)
 	if err != nil {
 		return nil, err
 	}
@@ -211,11 +299,13 @@ func (u *Updater) UpdateCurrentWeek() ([]int64, error) {
 		return nil, err
 	}
 
-	return u.processGames(games)
+	gameIDs, err := u.processGames(ctx, games)
+	// games stored before an error still reach the Elo history
+	return gameIDs, errors.Join(err, u.updateEloHistory(gameIDs))
 }
 
-func (u *Updater) UpdateGamesForYear(year int64) ([]int64, error) {
-	games, err := game.GetGamesForSeason(u.ESPN, year)
+func (u *Updater) UpdateGamesForYear(ctx context.Context, year int64) ([]int64, error) {
+	games, err := game.GetGamesForSeason(ctx, u.ESPN, year)
 	if err != nil {
 		return nil, err
 	}
@@ -225,14 +315,66 @@ func (u *Updater) UpdateGamesForYear(year int64) ([]int64, error) {
 		return nil, err
 	}
 
-	return u.processGames(games)
+	gameIDs, err := u.processGames(ctx, games)
+	// games stored before an error still reach the Elo history
+	return gameIDs, errors.Join(err, u.updateEloHistory(gameIDs))
 }
 
-func (u *Updater) UpdateSingleGame(gameID int64) error {
-	gameStats, err := game.GetSingleGame(u.ESPN, gameID)
+func (u *Updater) UpdateSingleGame(ctx context.Context, gameID int64) error {
+	gameStats, err := game.GetSingleGame(ctx, u.ESPN, gameID)
 	if err != nil {
 		return err
 	}
 
-	return u.insertGameInfo(gameStats)
+	if err := u.insertGameInfo(gameStats); err != nil {
+		return err
+	}
+
+	return u.updateEloHistory([]int64{gameStats.GameInfo.GameID})
+}
+
+// ReparseGamesForYear rebuilds a season's final games from the box scores in
+// the response archive, without network access, so a parser fix can be
+// applied to history. Games missing from the archive are left as stored.
+func (u *Updater) ReparseGamesForYear(ctx context.Context, year int64) ([]int64, error) {
+	if u.ArchiveDir == "" {
+		return nil, errors.New("no response archive configured (set ESPN_ARCHIVE_DIR)")
+	}
+
+	var ids []int64
+	if err := u.DB.Model(&database.Game{}).
+		Where("sport = ? and season = ? and status = ?", u.sportDB(), year, database.GameFinal).
+		Order("game_id").
+		Pluck("game_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	sport := u.ESPN.SportInfo()
+	var gameIDs []int64
+	var missing int
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return gameIDs, errors.Join(err, u.updateEloHistory(gameIDs))
+		}
+
+		res, err := espn.ArchivedGameStats(u.ArchiveDir, sport, id)
+		if errors.Is(err, fs.ErrNotExist) {
+			missing++
+			continue
+		}
+		if err != nil {
+			u.Logger.Warnf("skipping game %d: %v", id, err)
+			continue
+		}
+
+		if err := u.insertGameInfo(game.ParseGame(sport, res)); err != nil {
+			return gameIDs, errors.Join(err, u.updateEloHistory(gameIDs))
+		}
+		gameIDs = append(gameIDs, id)
+	}
+	if missing > 0 {
+		u.Logger.Warnf("%d of %d final games in %d are not archived", missing, len(ids), year)
+	}
+
+	return gameIDs, u.updateEloHistory(gameIDs)
 }
@@ -0,0 +1,54 @@
+package updater
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/robby-barton/stats-go/internal/database"
+	"github.com/robby-barton/stats-go/internal/simulate"
+)
+
+// UpdateSeasonSimulations simulates the rest of the season from the start of
+// the given week (0 for the current week) for every division of the sport and
+// stores the results. It returns the number of teams simulated.
+func (u *Updater) UpdateSeasonSimulations(year int64, week int64, runs int, topN int, seed int64) (int, error) {
+	divisions := []database.Division{database.DivisionFBS}
+	if u.sportDB() == "ncaaf" {
+		divisions = database.FootballDivisions()
+	}
+
+	var results []database.SeasonSimulation
+	for _, division := range divisions {
+		s := simulate.Simulator{
+			DB:       u.DB,
+			Sport:    u.sportDB(),
+			Year:     year,
+			Week:     week,
+			Division: division,
+			Runs:     runs,
+			TopN:     topN,
+			Seed:     seed,
+		}
+		simulations, err := s.Run()
+		if err != nil {
+			return 0, err
+		}
+		results = append(results, simulations...)
+	}
+
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	if err := u.DB.Transaction(func(tx *gorm.DB) error {
+		return tx.
+			Clauses(clause.OnConflict{
+				UpdateAll: true, // upsert
+			}).
+			CreateInBatches(results, 1000).Error
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(results), nil
+}
@@ -1,7 +1,7 @@
 package updater
 
 import (
-	"maps"
+	"context"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -35,21 +35,21 @@ func (u *Updater) seasonsExist(year int64) bool {
 }
 
 // UpdateTeamSeasons updates team season records for the current ESPN season.
-func (u *Updater) UpdateTeamSeasons(force bool) (int, error) {
-	currentSeason, err := u.ESPN.DefaultSeason()
+func (u *Updater) UpdateTeamSeasons(ctx context.Context, force bool) (int, error) {
+	currentSeason, err := u.ESPN.DefaultSeason(ctx)
 	if err != nil {
 		return 0, err
 	}
-	return u.updateTeamSeasonsForYear(currentSeason, force)
+	return u.updateTeamSeasonsForYear(ctx, currentSeason, force)
 }
 
 // UpdateTeamSeasonsForYear updates team season records for a specific year.
 // Use force=true to overwrite existing records.
-func (u *Updater) UpdateTeamSeasonsForYear(year int64, force bool) (int, error) {
-	return u.updateTeamSeasonsForYear(year, force)
+func (u *Updater) UpdateTeamSeasonsForYear(ctx context.Context, year int64, force bool) (int, error) {
+	return u.updateTeamSeasonsForYear(ctx, year, force)
 }
 
-func (u *Updater) updateTeamSeasonsForYear(year int64, force bool) (int, error) {
+func (u *Updater) updateTeamSeasonsForYear(ctx context.Context, year int64, force bool) (int, error) {
 	if !force && u.seasonsExist(year) {
 		u.Logger.Info("Not updating")
 		return 0, nil
@@ -57,21 +57,21 @@ func (u *Updater) updateTeamSeasonsForYear(year int64, force bool) (int, error)
 
 	sport := u.sportDB()
 
-	teamConfs, err := u.ESPN.TeamConferencesByYear(year)
+	teamConfs, err := u.ESPN.TeamConferencesByYear(ctx, year)
 	if err != nil {
 		return 0, err
 	}
 
 	var teamSeasons []database.TeamSeason
 
-	confResult, err := u.ESPN.ConferenceMap()
+	confResult, err := u.ESPN.ConferenceMap(ctx)
 	if err != nil {
 		return 0, err
 	}
 
 	if u.ESPN.SportInfo() == espn.CollegeBasketball {
-		// Basketball: all D1 teams are top-division (FBS=1). Conference names
-		// come from the conference API but there's no FBS/FCS split.
+		// Basketball: all D1 teams are stored as the top division. Conference
+		// names come from the conference API but there's no division split.
 		d1Confs := confResult.Conferences[espn.D1Basketball]
 
 		for team, conf := range teamConfs {
@@ -80,34 +80,42 @@ func (u *Updater) updateTeamSeasonsForYear(year int64, force bool) (int, error)
 				continue // skip non-D1 teams (e.g. D2/D3/NAIA opponents)
 			}
 			teamSeasons = append(teamSeasons, database.TeamSeason{
-				TeamID: team,
-				Conf:   confName,
-				Year:   year,
-				Sport:  sport,
-				FBS:    1, // all D1 basketball teams treated as top-division
+				TeamID:   team,
+				Conf:     confName,
+				Year:     year,
+				Sport:    sport,
+				Division: database.DivisionFBS, // all D1 basketball teams treated as top-division
+				FBS:      1,
 			})
 		}
 	} else {
-		fbs := confResult.Conferences[espn.FBS]
-		fbsfcs := maps.Clone(fbs)
-		maps.Copy(fbsfcs, confResult.Conferences[espn.FCS])
+		groups := map[espn.Group]database.Division{ //nolint:exhaustive // football doesn't have D1Basketball
+			espn.FBS:  database.DivisionFBS,
+			espn.FCS:  database.DivisionFCS,
+			espn.DII:  database.DivisionII,
+			espn.DIII: database.DivisionIII,
+		}
 
 		for team, conf := range teamConfs {
-			confName, ok := fbsfcs[conf]
-			if !ok {
-				continue
-			}
-			var isFBS int64
-			if _, ok := fbs[conf]; ok {
-				isFBS = 1
+			for group, division := range groups {
+				confName, ok := confResult.Conferences[group][conf]
+				if !ok {
+					continue
+				}
+				var fbs int64
+				if division.IsFBS() {
+					fbs = 1
+				}
+				teamSeasons = append(teamSeasons, database.TeamSeason{
+					TeamID:   team,
+					Conf:     confName,
+					Year:     year,
+					Sport:    sport,
+					Division: division,
+					FBS:      fbs,
+				})
+				break
 			}
-			teamSeasons = append(teamSeasons, database.TeamSeason{
-				TeamID: team,
-				Conf:   confName,
-				Year:   year,
-				Sport:  sport,
-				FBS:    isFBS,
-			})
 		}
 	}
 
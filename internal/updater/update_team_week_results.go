@@ -1,6 +1,15 @@
 package updater
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -15,11 +24,19 @@ type yearInfo struct {
 	Postseason int64
 }
 
-func (u *Updater) getYearInfo() ([]yearInfo, error) {
-	var yearInfo []yearInfo
-	if err := u.DB.Model(database.Game{}).
+// getYearInfo returns the seasons with final games from from to to, inclusive;
+// a zero end leaves that side open.
+func (u *Updater) getYearInfo(from int64, to int64) ([]yearInfo, error) {
+	tx := u.DB.Model(database.Game{}).
 		Select(`season as year, max(week) as weeks, max(postseason) as postseason`).
-		Where("sport = ? and season >= ?", u.sportDB(), 1936). // first official year of AP poll
+		Where("sport = ? and status = ? and season >= ?",
+			u.sportDB(), database.GameFinal, max(from, 1936)) // first official year of AP poll
+	if to > 0 {
+		tx = tx.Where("season <= ?", to)
+	}
+
+	var yearInfo []yearInfo
+	if err := tx.
 		Group("season").
 		Order("season").Find(&yearInfo).Error; err != nil {
 		return nil, err
@@ -35,7 +52,7 @@ func (u *Updater) getYearInfo() ([]yearInfo, error) {
 func (u *Updater) regularSeasonWeeks(year int64) ([]int64, error) {
 	var weeks []int64
 	if err := u.DB.Model(database.Game{}).
-		Where("sport = ? and season = ? and postseason = 0", u.sportDB(), year).
+		Where("sport = ? and status = ? and season = ? and postseason = 0", u.sportDB(), database.GameFinal, year).
 		Distinct("week").
 		Order("week").
 		Pluck("week", &weeks).Error; err != nil {
@@ -44,21 +61,50 @@ func (u *Updater) regularSeasonWeeks(year int64) ([]int64, error) {
 	return weeks, nil
 }
 
-func (u *Updater) insertRankingsToDB(rankings []database.TeamWeekResult) error {
-	return u.DB.Transaction(func(tx *gorm.DB) error {
+// weekRankings holds the team and conference rows of one or more rankings.
+type weekRankings struct {
+	teams       []database.TeamWeekResult
+	conferences []database.ConferenceWeekResult
+}
+
+func (w *weekRankings) add(other weekRankings) {
+	w.teams = append(w.teams, other.teams...)
+	w.conferences = append(w.conferences, other.conferences...)
+}
+
+func (u *Updater) insertRankingsToDB(ctx context.Context, rankings weekRankings) error {
+	if len(rankings.teams) == 0 {
+		return nil
+	}
+
+	return u.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.
 			Clauses(clause.OnConflict{
 				UpdateAll: true, // upsert
 			}).
-			CreateInBatches(rankings, 1000).Error; err != nil {
+			CreateInBatches(rankings.teams, 1000).Error; err != nil {
 			return err
 		}
 
+		if len(rankings.conferences) > 0 {
+			if err := tx.
+				Clauses(clause.OnConflict{
+					UpdateAll: true, // upsert
+				}).
+				CreateInBatches(rankings.conferences, 1000).Error; err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
 
-func teamListToTeamWeekResult(teamList ranking.TeamList, fbs bool, sport string) []database.TeamWeekResult {
+func teamListToTeamWeekResult(
+	teamList ranking.TeamList,
+	sport string,
+	model string,
+) []database.TeamWeekResult {
 	var retTWR []database.TeamWeekResult
 
 	for id, result := range teamList {
@@ -70,6 +116,7 @@ func teamListToTeamWeekResult(teamList ranking.TeamList, fbs bool, sport string)
 			Week:       result.Week,
 			Postseason: result.Postseason,
 			Sport:      sport,
+			Model:      model,
 			FinalRank:  result.FinalRank,
 			FinalRaw:   result.FinalRaw,
 			Wins:       result.Record.Wins,
@@ -77,102 +124,323 @@ func teamListToTeamWeekResult(teamList ranking.TeamList, fbs bool, sport string)
 			Ties:       result.Record.Ties,
 			SRSRank:    result.SRSRank,
 			SOSRank:    result.SOSRank,
-			Fbs:        fbs,
+			SOVRank:    result.SOVRank,
+			SOLRank:    result.SOLRank,
+			Division:   result.Division,
+			Fbs:        result.Division.IsFBS(),
+
+			FinalRawLow:  result.FinalRawLow,
+			FinalRawHigh: result.FinalRawHigh,
+			RankBest:     result.RankBest,
+			RankWorst:    result.RankWorst,
+			OverallRank:  result.OverallRank,
 		})
 	}
 
 	return retTWR
 }
 
-func (u *Updater) rankingForWeek(year int64, week int64) ([]database.TeamWeekResult, error) {
-	sport := u.sportDB()
-	var teamWeekResults []database.TeamWeekResult
+func conferencesToConferenceWeekResult(
+	conferences []ranking.ConferenceStrength,
+	division database.Division,
+	sport string,
+	model string,
+) []database.ConferenceWeekResult {
+	var retCWR []database.ConferenceWeekResult
 
-	if u.ESPN.SportInfo() == espn.CollegeBasketball {
+	for _, conf := range conferences {
+		retCWR = append(retCWR, database.ConferenceWeekResult{
+			Conf:          conf.Conf,
+			Year:          conf.Year,
+			Week:          conf.Week,
+			Postseason:    conf.Postseason,
+			Sport:         sport,
+			Model:         model,
+			Division:      division,
+			Fbs:           division.IsFBS(),
+			Teams:         conf.Teams,
+			Rank:          conf.Rank,
+			MeanRaw:       conf.MeanRaw,
+			MedianRaw:     conf.MedianRaw,
+			Depth:         conf.Depth,
+			BestRank:      conf.BestRank,
+			WorstRank:     conf.WorstRank,
+			NonConfWins:   conf.NonConf.Wins,
+			NonConfLosses: conf.NonConf.Losses,
+			NonConfTies:   conf.NonConf.Ties,
+		})
+	}
+
+	return retCWR
+}
+
+// rankingForWeek ranks every division of a week with each stored model. A nil
+// games reads the games from the database.
+func (u *Updater) rankingForWeek(
+	year int64,
+	week int64,
+	games ranking.GameSource,
+) (weekRankings, error) {
+	var divisions []ranking.Ranker
+	switch {
+	case u.ESPN.SportInfo() == espn.CollegeBasketball:
 		// Basketball: single D1 ranking, no FBS/FCS split
-		ranker := ranking.Ranker{
-			DB:    u.DB,
-			Year:  year,
-			Week:  week,
-			Sport: sport,
-		}
-		teamList, err := ranker.CalculateRanking()
-		if err != nil {
-			return nil, err
+		divisions = []ranking.Ranker{{}}
+	case u.Unified:
+		divisions = []ranking.Ranker{
+			{Unified: true}, {Division: database.DivisionII}, {Division: database.DivisionIII},
 		}
-		teamWeekResults = append(teamWeekResults, teamListToTeamWeekResult(teamList, true, sport)...)
-	} else {
-		fbsRanker := ranking.Ranker{
-			DB:    u.DB,
-			Year:  year,
-			Week:  week,
-			Sport: sport,
+	default:
+		for _, division := range database.FootballDivisions() {
+			divisions = append(divisions, ranking.Ranker{Division: division})
 		}
-		fbsRanking, err := fbsRanker.CalculateRanking()
-		if err != nil {
-			return nil, err
-		}
-		teamWeekResults = append(teamWeekResults, teamListToTeamWeekResult(fbsRanking, true, sport)...)
+	}
 
-		fcsRanker := ranking.Ranker{
-			DB:    u.DB,
-			Year:  year,
-			Week:  week,
-			Fcs:   true,
-			Sport: sport,
+	var rankings weekRankings
+	for _, model := range u.models() {
+		for _, division := range divisions {
+			results, err := u.rankDivision(year, week, division, model, games)
+			if err != nil {
+				return weekRankings{}, err
+			}
+			rankings.add(results)
 		}
-		fcsRanking, err := fcsRanker.CalculateRanking()
+	}
+
+	return rankings, nil
+}
+
+// rankDivision ranks the division selected by ranker's Division and Unified. The
+// conferences of a unified ranking are ranked within their own division.
+func (u *Updater) rankDivision(
+	year int64,
+	week int64,
+	ranker ranking.Ranker,
+	model string,
+	games ranking.GameSource,
+) (weekRankings, error) {
+	sport := u.sportDB()
+	ranker.DB = u.DB
+	ranker.Year = year
+	ranker.Week = week
+	ranker.Sport = sport
+	ranker.Model = model
+	ranker.Games = games
+	if model == ranking.DefaultModel {
+		ranker.Resamples = u.Resamples
+	}
+	teamList, err := ranker.CalculateRanking()
+	if err != nil {
+		return weekRankings{}, err
+	}
+
+	results := weekRankings{teams: teamListToTeamWeekResult(teamList, sport, model)}
+	for name, division := range splitDivisions(teamList) {
+		conferences, err := ranker.Conferences(division)
 		if err != nil {
-			return nil, err
+			return weekRankings{}, err
 		}
-		teamWeekResults = append(teamWeekResults, teamListToTeamWeekResult(fcsRanking, false, sport)...)
+		results.conferences = append(results.conferences,
+			conferencesToConferenceWeekResult(conferences, name, sport, model)...)
 	}
 
-	return teamWeekResults, nil
+	return results, nil
+}
+
+// splitDivisions groups a ranked team list by division.
+func splitDivisions(teamList ranking.TeamList) map[database.Division]ranking.TeamList {
+	divisions := map[database.Division]ranking.TeamList{}
+	for id, team := range teamList {
+		if divisions[team.Division] == nil {
+			divisions[team.Division] = ranking.TeamList{}
+		}
+		divisions[team.Division][id] = team
+	}
+	return divisions
 }
 
-func (u *Updater) UpdateRecentRankings() error {
-	weekRankings, err := u.rankingForWeek(0, 0)
+func (u *Updater) UpdateRecentRankings(ctx context.Context) error {
+	weekRankings, err := u.rankingForWeek(0, 0, nil)
 	if err != nil {
 		return err
 	}
 
-	return u.insertRankingsToDB(weekRankings)
+	return u.insertRankingsToDB(ctx, weekRankings)
 }
 
-func (u *Updater) UpdateAllRankings() error {
-	var teamWeekResults []database.TeamWeekResult
+// UpdateRankingsForGames recomputes the rankings that count any of the games:
+// the weeks of each game's season after it was played, with the season's
+// final or current ranking, and every week of the next ranking.YearsBack
+// seasons that have rankings stored, since SRS backfills from them. Use it
+// when stored games change after they were first ranked.
+func (u *Updater) UpdateRankingsForGames(ctx context.Context, gameIDs []int64) error {
+	if len(gameIDs) == 0 {
+		return nil
+	}
 
-	yearInfo, err := u.getYearInfo()
-	if err != nil {
+	sport := u.sportDB()
+	var games []database.Game
+	if err := u.DB.Select("season", "week", "postseason").
+		Where("game_id in ? and sport = ?", gameIDs, sport).
+		Find(&games).Error; err != nil {
 		return err
 	}
+	if len(games) == 0 {
+		return nil
+	}
 
-	for _, year := range yearInfo {
-		weeks, err := u.regularSeasonWeeks(year.Year)
+	// first regular-season week whose ranking counts a game, by season
+	firstWeek := map[int64]int64{}
+	later := map[int64]bool{}
+	for _, game := range games {
+		first := game.Week + 1
+		if game.Postseason > 0 {
+			// only the final ranking
+			first = math.MaxInt64
+		}
+		if current, ok := firstWeek[game.Season]; !ok || first < current {
+			firstWeek[game.Season] = first
+		}
+		for season := game.Season + 1; season <= game.Season+ranking.YearsBack(sport); season++ {
+			later[season] = true
+		}
+	}
+
+	var laterSeasons []int64
+	for season := range later {
+		laterSeasons = append(laterSeasons, season)
+	}
+	var ranked []int64
+	if err := u.DB.Model(database.TeamWeekResult{}).
+		Where("sport = ? and year in ?", sport, laterSeasons).
+		Distinct("year").
+		Pluck("year", &ranked).Error; err != nil {
+		return err
+	}
+	for _, season := range ranked {
+		firstWeek[season] = 0
+	}
+
+	seasons := slices.Sorted(maps.Keys(firstWeek))
+	for _, season := range seasons {
+		regularWeeks, err := u.regularSeasonWeeks(season)
 		if err != nil {
 			return err
 		}
-		for _, week := range weeks {
-			u.Logger.Infof("%d/%d", year.Year, week)
-			weekRankings, err := u.rankingForWeek(year.Year, week)
-			if err != nil {
-				return err
+		var weeks []int64
+		for _, week := range regularWeeks {
+			if week >= firstWeek[season] {
+				weeks = append(weeks, week)
 			}
-			teamWeekResults = append(teamWeekResults, weekRankings...)
 		}
 		// postseason or current week
-		if year.Postseason == 1 {
-			u.Logger.Infof("%d/Final", year.Year)
-		} else {
-			u.Logger.Infof("%d/%d", year.Year, year.Weeks+1)
+		weeks = append(weeks, 0)
+
+		seasonRankings, err := u.rankingsForSeason(ctx, season, weeks, nil)
+		if err != nil {
+			return fmt.Errorf("%d: %w", season, err)
+		}
+		if err := u.insertRankingsToDB(ctx, seasonRankings); err != nil {
+			return fmt.Errorf("%d: %w", season, err)
+		}
+		u.Logger.Infof("%d: re-ranked %d weeks", season, len(weeks))
+	}
+
+	return nil
+}
+
+// UpdateAllRankings recomputes every stored week of the seasons from from to
+// to, inclusive; a zero end leaves that side open. Each season's weeks are
+// ranked concurrently by a bounded pool of workers and stored together before
+// the next season starts, so a failure or cancellation keeps every season
+// before it.
+func (u *Updater) UpdateAllRankings(ctx context.Context, from int64, to int64) error {
+	yearInfo, err := u.getYearInfo(from, to)
+	if err != nil {
+		return err
+	}
+
+	sport := u.sportDB()
+	start := time.Now()
+	for i, year := range yearInfo {
+		// every week of the season reads from one load of its games and the
+		// seasons SRS backfills from, rather than the database; holding one
+		// season at a time keeps memory flat however many are recomputed
+		games, err := ranking.LoadGameSource(u.DB, sport, year.Year-ranking.YearsBack(sport), year.Year)
+		if err != nil {
+			return err
 		}
-		weekRankings, err := u.rankingForWeek(year.Year, 0)
+
+		weeks, err := u.regularSeasonWeeks(year.Year)
 		if err != nil {
 			return err
 		}
-		teamWeekResults = append(teamWeekResults, weekRankings...)
+		// postseason or current week
+		weeks = append(weeks, 0)
+
+		seasonRankings, err := u.rankingsForSeason(ctx, year.Year, weeks, games)
+		if err != nil {
+			return fmt.Errorf("%d: %w", year.Year, err)
+		}
+		if err := u.insertRankingsToDB(ctx, seasonRankings); err != nil {
+			return fmt.Errorf("%d: %w", year.Year, err)
+		}
+
+		final := "final"
+		if year.Postseason == 0 {
+			final = fmt.Sprintf("week %d", year.Weeks+1)
+		}
+		u.Logger.Infof("%d: ranked %d weeks through %s (%d/%d seasons, %s)",
+			year.Year, len(weeks), final, i+1, len(yearInfo), time.Since(start).Round(time.Second))
 	}
 
-	return u.insertRankingsToDB(teamWeekResults)
+	return nil
+}
+
+// rankingsForSeason ranks the given weeks of a season on up to workers()
+// goroutines and returns the results in week order. Once ctx is done no more
+// weeks are started, and the weeks already running are discarded.
+func (u *Updater) rankingsForSeason(
+	ctx context.Context,
+	year int64,
+	weeks []int64,
+	games ranking.GameSource,
+) (weekRankings, error) {
+	results := make([]weekRankings, len(weeks))
+	errs := make([]error, len(weeks))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(u.workers(), len(weeks)) {
+		wg.Go(func() {
+			for i := range jobs {
+				results[i], errs[i] = u.rankingForWeek(year, weeks[i], games)
+				if errs[i] != nil {
+					errs[i] = fmt.Errorf("week %d: %w", weeks[i], errs[i])
+				}
+			}
+		})
+	}
+	for i := range weeks {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return weekRankings{}, err
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return weekRankings{}, err
+	}
+
+	var seasonRankings weekRankings
+	for _, rankings := range results {
+		seasonRankings.add(rankings)
+	}
+	return seasonRankings, nil
 }
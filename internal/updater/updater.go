@@ -1,16 +1,64 @@
 package updater
 
 import (
+	"runtime"
+
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/robby-barton/stats-go/internal/espn"
+	"github.com/robby-barton/stats-go/internal/ranking"
 )
 
 type Updater struct {
 	DB     *gorm.DB
 	Logger *zap.SugaredLogger
 	ESPN   espn.SportClient
+	Models []string // rating models to store; empty means ranking.DefaultModel
+	// Resamples is passed to the composite ranker to store rank ranges; 0
+	// stores point rankings only.
+	Resamples int
+	// Workers is the number of weeks UpdateAllRankings ranks at once; 0 means
+	// one per CPU.
+	Workers int
+	// FetchWorkers is the number of box scores fetched and parsed at once; 0
+	// means defaultFetchWorkers. The ESPN client's rate limiter still bounds
+	// the request rate.
+	FetchWorkers int
+	// Unified ranks football's FBS and FCS together on one scale, storing
+	// each team's division rank and its rank across both.
+	Unified bool
+	// ArchiveDir is the ESPN response archive ReparseGamesForYear reads
+	// from; the ESPN client should archive to the same directory.
+	ArchiveDir string
+}
+
+// models returns the rating models whose rankings the updater stores.
+func (u *Updater) models() []string {
+	if len(u.Models) == 0 {
+		return []string{ranking.DefaultModel}
+	}
+	return u.Models
+}
+
+// workers returns how many weeks UpdateAllRankings ranks at once.
+func (u *Updater) workers() int {
+	if u.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return u.Workers
+}
+
+// defaultFetchWorkers is enough concurrent box score fetches to keep the
+// shared ESPN rate limiter busy through request latency.
+const defaultFetchWorkers = 4
+
+// fetchWorkers returns how many box scores processGames fetches at once.
+func (u *Updater) fetchWorkers() int {
+	if u.FetchWorkers <= 0 {
+		return defaultFetchWorkers
+	}
+	return u.FetchWorkers
 }
 
 // sportDB returns the short database identifier for the updater's sport.
@@ -34,14 +34,14 @@ func bbFixtureScheduleResponse() espn.GameScheduleESPN {
 			Schedule: map[string]espn.Day{
 				"2024-01-06": {
 					Games: []espn.Game{
-						newFinalGame(bbFixtureGameID1, 11, 300, 78, 12, 300, 65),
-						newFinalGame(bbFixtureGameID2, 13, 400, 70, 14, 400, 68),
-						newInProgressGame(bbFixtureGameID3, 11, 300, 40, 13, 400, 38),
+						newFinalGame(bbFixtureGameID1, "2024-01-06T19:00Z", 11, 300, 78, 12, 300, 65),
+						newFinalGame(bbFixtureGameID2, "2024-01-06T19:00Z", 13, 400, 70, 14, 400, 68),
+						newInProgressGame(bbFixtureGameID3, "2024-01-06T19:00Z", 11, 300, 40, 13, 400, 38),
 					},
 				},
 				"2024-01-13": {
 					Games: []espn.Game{
-						newFinalGame(bbFixtureGameID4, 11, 300, 80, 13, 400, 75),
+						newFinalGame(bbFixtureGameID4, "2024-01-13T19:00Z", 11, 300, 80, 13, 400, 75),
 					},
 				},
 			},
@@ -257,7 +257,6 @@ func newBasketballTestUpdater(t *testing.T) *Updater {
 		MaxRetries:     2,
 		InitialBackoff: 10 * time.Millisecond,
 		RequestTimeout: 5 * time.Second,
-		RateLimit:      0,
 		Sport:          espn.CollegeBasketball,
 	}}
 
@@ -290,10 +289,10 @@ func seedBasketballTeamsAndSeasons(t *testing.T, db *gorm.DB) {
 	}
 
 	teamSeasons := []database.TeamSeason{
-		{TeamID: 11, Year: 2024, FBS: 1, Conf: "Big East", Sport: "ncaam"},
-		{TeamID: 12, Year: 2024, FBS: 1, Conf: "Big East", Sport: "ncaam"},
-		{TeamID: 13, Year: 2024, FBS: 1, Conf: "ACC", Sport: "ncaam"},
-		{TeamID: 14, Year: 2024, FBS: 1, Conf: "ACC", Sport: "ncaam"},
+		{TeamID: 11, Year: 2024, Division: database.DivisionFBS, Conf: "Big East", Sport: "ncaam"},
+		{TeamID: 12, Year: 2024, Division: database.DivisionFBS, Conf: "Big East", Sport: "ncaam"},
+		{TeamID: 13, Year: 2024, Division: database.DivisionFBS, Conf: "ACC", Sport: "ncaam"},
+		{TeamID: 14, Year: 2024, Division: database.DivisionFBS, Conf: "ACC", Sport: "ncaam"},
 	}
 	if err := db.Create(&teamSeasons).Error; err != nil {
 		t.Fatalf("seed basketball team_seasons: %v", err)
@@ -336,7 +335,7 @@ func seedBasketballGames(t *testing.T, db *gorm.DB) {
 func TestBasketball_UpdateSingleGame(t *testing.T) {
 	u := newBasketballTestUpdater(t)
 
-	if err := u.UpdateSingleGame(bbFixtureGameID1); err != nil {
+	if err := u.UpdateSingleGame(t.Context(), bbFixtureGameID1); err != nil {
 		t.Fatalf("UpdateSingleGame: %v", err)
 	}
 
@@ -376,7 +375,7 @@ func TestBasketball_UpdateSingleGame(t *testing.T) {
 func TestBasketball_UpdateCurrentWeek(t *testing.T) {
 	u := newBasketballTestUpdater(t)
 
-	gameIDs, err := u.UpdateCurrentWeek()
+	gameIDs, err := u.UpdateCurrentWeek(t.Context())
 	if err != nil {
 		t.Fatalf("UpdateCurrentWeek: %v", err)
 	}
@@ -407,7 +406,7 @@ func TestBasketball_UpdateCurrentWeek(t *testing.T) {
 	}
 
 	// Re-run should be a no-op
-	gameIDs2, err := u.UpdateCurrentWeek()
+	gameIDs2, err := u.UpdateCurrentWeek(t.Context())
 	if err != nil {
 		t.Fatalf("UpdateCurrentWeek re-run: %v", err)
 	}
@@ -419,7 +418,7 @@ func TestBasketball_UpdateCurrentWeek(t *testing.T) {
 func TestBasketball_UpdateTeamSeasons(t *testing.T) {
 	u := newBasketballTestUpdater(t)
 
-	count, err := u.UpdateTeamSeasons(true)
+	count, err := u.UpdateTeamSeasons(t.Context(), true)
 	if err != nil {
 		t.Fatalf("UpdateTeamSeasons: %v", err)
 	}
@@ -436,10 +435,10 @@ func TestBasketball_UpdateTeamSeasons(t *testing.T) {
 		t.Error("no team_season rows found")
 	}
 
-	// All basketball teams should be FBS=1 (D1)
+	// All basketball teams should be top-division (D1)
 	for _, s := range seasons {
-		if s.FBS != 1 {
-			t.Errorf("team %d FBS = %d, want 1 (all D1 basketball)", s.TeamID, s.FBS)
+		if s.Division != database.DivisionFBS {
+			t.Errorf("team %d Division = %q, want fbs (all D1 basketball)", s.TeamID, s.Division)
 		}
 		if s.Sport != "ncaam" {
 			t.Errorf("team %d Sport = %q, want %q", s.TeamID, s.Sport, "ncaam")
@@ -453,7 +452,7 @@ func TestBasketball_RankingForWeek(t *testing.T) {
 	seedBasketballTeamsAndSeasons(t, u.DB)
 	seedBasketballGames(t, u.DB)
 
-	if err := u.UpdateRecentRankings(); err != nil {
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
 		t.Fatalf("UpdateRecentRankings: %v", err)
 	}
 
@@ -465,10 +464,10 @@ func TestBasketball_RankingForWeek(t *testing.T) {
 		t.Fatal("no ranking results found")
 	}
 
-	// All basketball results should have Fbs=true and Sport="ncaam"
+	// All basketball results should be top-division and Sport="ncaam"
 	for _, r := range results {
-		if !r.Fbs {
-			t.Errorf("team %d Fbs = false, want true (basketball single D1 ranking)", r.TeamID)
+		if r.Division != database.DivisionFBS {
+			t.Errorf("team %d Division = %q, want fbs (basketball single D1 ranking)", r.TeamID, r.Division)
 		}
 		if r.Sport != "ncaam" {
 			t.Errorf("team %d Sport = %q, want %q", r.TeamID, r.Sport, "ncaam")
@@ -3,16 +3,20 @@
 package updater
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/robby-barton/stats-go/internal/database"
 	"github.com/robby-barton/stats-go/internal/espn"
+	"github.com/robby-barton/stats-go/internal/ranking"
 )
 
 func TestUpdateSingleGame(t *testing.T) {
 	u := newTestUpdater(t, nil)
 
-	if err := u.UpdateSingleGame(fixtureGameID1); err != nil {
+	if err := u.UpdateSingleGame(t.Context(), fixtureGameID1); err != nil {
 		t.Fatalf("UpdateSingleGame: %v", err)
 	}
 
@@ -53,7 +57,7 @@ func TestUpdateSingleGame(t *testing.T) {
 func TestUpdateCurrentWeek(t *testing.T) {
 	u := newTestUpdater(t, nil)
 
-	gameIDs, err := u.UpdateCurrentWeek()
+	gameIDs, err := u.UpdateCurrentWeek(t.Context())
 	if err != nil {
 		t.Fatalf("UpdateCurrentWeek: %v", err)
 	}
@@ -76,15 +80,21 @@ func TestUpdateCurrentWeek(t *testing.T) {
 		}
 	}
 
-	// Verify games in DB
+	// Verify games in DB: the 4 finals plus the 2 in-progress games from the
+	// schedule.
 	var count int64
-	u.DB.Model(&database.Game{}).Count(&count)
+	u.DB.Model(&database.Game{}).Where("status = ?", database.GameFinal).Count(&count)
 	if count != 4 {
-		t.Errorf("game count = %d, want 4", count)
+		t.Errorf("final game count = %d, want 4", count)
+	}
+	var inProgress []database.Game
+	u.DB.Where("status = ?", database.GameInProgress).Find(&inProgress)
+	if len(inProgress) != 2 {
+		t.Errorf("in-progress game count = %d, want 2", len(inProgress))
 	}
 
 	// Re-run should be a no-op (checkGames filters already-stored games with matching scores)
-	gameIDs2, err := u.UpdateCurrentWeek()
+	gameIDs2, err := u.UpdateCurrentWeek(t.Context())
 	if err != nil {
 		t.Fatalf("UpdateCurrentWeek re-run: %v", err)
 	}
@@ -93,11 +103,62 @@ func TestUpdateCurrentWeek(t *testing.T) {
 	}
 }
 
+func TestUpdateCurrentWeek_EloHistory(t *testing.T) {
+	u := newTestUpdater(t, nil)
+
+	if _, err := u.UpdateCurrentWeek(t.Context()); err != nil {
+		t.Fatalf("UpdateCurrentWeek: %v", err)
+	}
+
+	// Each of the 4 final games adds a row for both teams.
+	var count int64
+	u.DB.Model(&database.EloHistory{}).Count(&count)
+	if count != 8 {
+		t.Errorf("elo history rows = %d, want 8", count)
+	}
+
+	applied, err := u.RebuildEloHistory()
+	if err != nil {
+		t.Fatalf("RebuildEloHistory: %v", err)
+	}
+	if applied != 4 {
+		t.Errorf("rebuild applied %d games, want 4", applied)
+	}
+	u.DB.Model(&database.EloHistory{}).Count(&count)
+	if count != 8 {
+		t.Errorf("elo history rows after rebuild = %d, want 8", count)
+	}
+}
+
+func TestUpdateSeasonSimulations(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+
+	simulated, err := u.UpdateSeasonSimulations(0, 0, 100, 2, 1)
+	if err != nil {
+		t.Fatalf("UpdateSeasonSimulations: %v", err)
+	}
+	if simulated == 0 {
+		t.Fatal("no teams simulated")
+	}
+
+	// A second run replaces the rows rather than adding to them.
+	if _, err := u.UpdateSeasonSimulations(0, 0, 100, 2, 1); err != nil {
+		t.Fatalf("second UpdateSeasonSimulations: %v", err)
+	}
+	var count int64
+	u.DB.Model(&database.SeasonSimulation{}).Count(&count)
+	if count != int64(simulated) {
+		t.Errorf("season simulation rows = %d, want %d", count, simulated)
+	}
+}
+
 func TestUpdateCurrentWeek_ScoreChange(t *testing.T) {
 	// First run: normal scores
 	u := newTestUpdater(t, nil)
 
-	_, err := u.UpdateCurrentWeek()
+	_, err := u.UpdateCurrentWeek(t.Context())
 	if err != nil {
 		t.Fatalf("initial UpdateCurrentWeek: %v", err)
 	}
@@ -118,7 +179,7 @@ func TestUpdateCurrentWeek_ScoreChange(t *testing.T) {
 	restore := newTestURLs(t, ts2.URL)
 	defer restore()
 
-	gameIDs, err := u.UpdateCurrentWeek()
+	gameIDs, err := u.UpdateCurrentWeek(t.Context())
 	if err != nil {
 		t.Fatalf("UpdateCurrentWeek with score change: %v", err)
 	}
@@ -141,10 +202,85 @@ func TestUpdateCurrentWeek_ScoreChange(t *testing.T) {
 	}
 }
 
+func TestCheckGames_ScheduleChange(t *testing.T) {
+	u := newTestUpdater(t, nil)
+
+	if _, err := u.UpdateCurrentWeek(t.Context()); err != nil {
+		t.Fatalf("UpdateCurrentWeek: %v", err)
+	}
+
+	moved := newFinalGame(fixtureGameID1, "2023-09-03T01:00Z", 1, 100, 28, 2, 100, 14)
+	relocated := newFinalGame(fixtureGameID2, "2023-09-02T23:00Z", 3, 200, 21, 4, 200, 10)
+	relocated.Competitions[0].Venue.FullName = "Neutral Field"
+	unchanged := newFinalGame(fixtureGameID4, "2023-09-09T23:00Z", 1, 100, 35, 3, 200, 17)
+
+	games, err := u.checkGames([]espn.Game{moved, relocated, unchanged})
+	if err != nil {
+		t.Fatalf("checkGames: %v", err)
+	}
+	if len(games) != 2 || games[0].ID != fixtureGameID1 || games[1].ID != fixtureGameID2 {
+		t.Errorf("checkGames returned %v, want the moved and relocated games", games)
+	}
+}
+
+func TestReparseGamesForYear(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	u.ArchiveDir = t.TempDir()
+	u.ESPN.(*espn.FootballClient).ArchiveDir = u.ArchiveDir
+
+	stored, err := u.UpdateCurrentWeek(t.Context())
+	if err != nil {
+		t.Fatalf("UpdateCurrentWeek: %v", err)
+	}
+
+	// damage a stored game so the reparse has something to fix
+	if err := u.DB.Model(&database.Game{}).
+		Where("game_id = ?", fixtureGameID1).
+		Update("home_score", 0).Error; err != nil {
+		t.Fatalf("update game: %v", err)
+	}
+	if err := u.DB.Where("game_id = ?", fixtureGameID1).Delete(&database.PassingStats{}).Error; err != nil {
+		t.Fatalf("delete passing stats: %v", err)
+	}
+
+	// the reparse must not touch the network
+	restore := newTestURLs(t, "http://127.0.0.1:0")
+	defer restore()
+
+	gameIDs, err := u.ReparseGamesForYear(t.Context(), 2023)
+	if err != nil {
+		t.Fatalf("ReparseGamesForYear: %v", err)
+	}
+	if len(gameIDs) != len(stored) {
+		t.Errorf("reparsed %d games, want %d", len(gameIDs), len(stored))
+	}
+
+	var game database.Game
+	if err := u.DB.Where("game_id = ?", fixtureGameID1).First(&game).Error; err != nil {
+		t.Fatalf("game not found: %v", err)
+	}
+	if game.HomeScore != 28 {
+		t.Errorf("home score = %d, want 28", game.HomeScore)
+	}
+	var passStats int64
+	u.DB.Model(&database.PassingStats{}).Where("game_id = ?", fixtureGameID1).Count(&passStats)
+	if passStats == 0 {
+		t.Error("expected passing stats to be restored, got none")
+	}
+}
+
+func TestReparseGamesForYear_NoArchive(t *testing.T) {
+	u := newTestUpdater(t, nil)
+
+	if _, err := u.ReparseGamesForYear(t.Context(), 2023); err == nil {
+		t.Error("expected an error without an archive dir")
+	}
+}
+
 func TestUpdateTeamInfo(t *testing.T) {
 	u := newTestUpdater(t, nil)
 
-	count, err := u.UpdateTeamInfo()
+	count, err := u.UpdateTeamInfo(t.Context())
 	if err != nil {
 		t.Fatalf("UpdateTeamInfo: %v", err)
 	}
@@ -174,7 +310,7 @@ func TestUpdateTeamInfo(t *testing.T) {
 func TestUpdateTeamSeasons(t *testing.T) {
 	u := newTestUpdater(t, nil)
 
-	count, err := u.UpdateTeamSeasons(true)
+	count, err := u.UpdateTeamSeasons(t.Context(), true)
 	if err != nil {
 		t.Fatalf("UpdateTeamSeasons: %v", err)
 	}
@@ -197,8 +333,11 @@ func TestUpdateTeamSeasons(t *testing.T) {
 	// Verify FBS assignment
 	for _, s := range seasons {
 		if s.TeamID >= 1 && s.TeamID <= 4 {
+			if s.Division != database.DivisionFBS {
+				t.Errorf("team %d Division = %q, want fbs", s.TeamID, s.Division)
+			}
 			if s.FBS != 1 {
-				t.Errorf("team %d FBS = %d, want 1", s.TeamID, s.FBS)
+				t.Errorf("team %d FBS = %d, want 1 alongside division", s.TeamID, s.FBS)
 			}
 		}
 	}
@@ -212,7 +351,7 @@ func TestRankingForWeek(t *testing.T) {
 	seedGames(t, u.DB)
 
 	// Run ranking
-	if err := u.UpdateRecentRankings(); err != nil {
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
 		t.Fatalf("UpdateRecentRankings: %v", err)
 	}
 
@@ -228,7 +367,10 @@ func TestRankingForWeek(t *testing.T) {
 	// Check that FBS teams got ranked
 	fbsResults := 0
 	for _, r := range results {
-		if r.Fbs {
+		if r.Fbs != r.Division.IsFBS() {
+			t.Errorf("team %d fbs = %v, want it to match division %q", r.TeamID, r.Fbs, r.Division)
+		}
+		if r.Division == database.DivisionFBS {
 			fbsResults++
 			if r.FinalRank == 0 {
 				t.Errorf("team %d has FinalRank 0", r.TeamID)
@@ -240,6 +382,310 @@ func TestRankingForWeek(t *testing.T) {
 	}
 }
 
+func TestUpdateAllRankings(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+
+	if err := u.UpdateAllRankings(t.Context(), 0, 0); err != nil {
+		t.Fatalf("UpdateAllRankings: %v", err)
+	}
+
+	var weeks []int64
+	if err := u.DB.Model(&database.TeamWeekResult{}).
+		Distinct("week").Order("week").Pluck("week", &weeks).Error; err != nil {
+		t.Fatalf("query weeks: %v", err)
+	}
+	if len(weeks) != 3 || weeks[0] != 1 || weeks[2] != 3 {
+		t.Fatalf("ranked weeks = %v, want [1 2 3]", weeks)
+	}
+
+	// Ranking from the loaded games matches ranking from the database.
+	var all []database.TeamWeekResult
+	u.DB.Where("week = ?", 3).Order("team_id, division").Find(&all)
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+	var recent []database.TeamWeekResult
+	u.DB.Where("week = ?", 3).Order("team_id, division").Find(&recent)
+	if len(all) == 0 || len(all) != len(recent) {
+		t.Fatalf("week 3 rows = %d, then %d", len(all), len(recent))
+	}
+	for i := range all {
+		if all[i] != recent[i] {
+			t.Errorf("team %d = %+v, want %+v", all[i].TeamID, all[i], recent[i])
+		}
+	}
+}
+
+func TestUpdateAllRankings_YearRange(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+	u.Workers = 1
+
+	// The seeded games are all 2023.
+	for _, years := range [][2]int64{{2024, 0}, {0, 2022}} {
+		if err := u.UpdateAllRankings(t.Context(), years[0], years[1]); err != nil {
+			t.Fatalf("UpdateAllRankings(%d, %d): %v", years[0], years[1], err)
+		}
+		var count int64
+		u.DB.Model(&database.TeamWeekResult{}).Count(&count)
+		if count != 0 {
+			t.Errorf("UpdateAllRankings(%d, %d) stored %d rows, want 0", years[0], years[1], count)
+		}
+	}
+
+	if err := u.UpdateAllRankings(t.Context(), 2023, 2023); err != nil {
+		t.Fatalf("UpdateAllRankings(2023, 2023): %v", err)
+	}
+	var weeks []int64
+	u.DB.Model(&database.TeamWeekResult{}).Distinct("week").Order("week").Pluck("week", &weeks)
+	if len(weeks) != 3 {
+		t.Errorf("ranked weeks = %v, want 3 weeks", weeks)
+	}
+}
+
+func TestUpdateAllRankings_Canceled(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if err := u.UpdateAllRankings(ctx, 0, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("UpdateAllRankings = %v, want context.Canceled", err)
+	}
+	var count int64
+	u.DB.Model(&database.TeamWeekResult{}).Count(&count)
+	if count != 0 {
+		t.Errorf("stored %d rows after cancellation, want 0", count)
+	}
+}
+
+func TestUpdateRankingsForGames(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+
+	if err := u.UpdateAllRankings(t.Context(), 0, 0); err != nil {
+		t.Fatalf("UpdateAllRankings: %v", err)
+	}
+	// A 2024 preseason ranking backfills from 2023.
+	var nextSeason []database.TeamSeason
+	u.DB.Where("year = ?", 2023).Find(&nextSeason)
+	for i := range nextSeason {
+		nextSeason[i].Year = 2024
+	}
+	if err := u.DB.Create(&nextSeason).Error; err != nil {
+		t.Fatalf("seed 2024 seasons: %v", err)
+	}
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+
+	// Correct a week 1 score. Week 1 rankings don't count it, so they must
+	// not be rewritten.
+	if err := u.DB.Model(&database.Game{}).Where("game_id = ?", fixtureGameID1).
+		Updates(map[string]any{"home_score": 10, "away_score": 14}).Error; err != nil {
+		t.Fatalf("update game: %v", err)
+	}
+	u.DB.Where("year = ? and week = ?", 2023, 1).Delete(&database.TeamWeekResult{})
+
+	if err := u.UpdateRankingsForGames(t.Context(), []int64{fixtureGameID1}); err != nil {
+		t.Fatalf("UpdateRankingsForGames: %v", err)
+	}
+
+	var count int64
+	u.DB.Model(&database.TeamWeekResult{}).Where("year = ? and week = ?", 2023, 1).Count(&count)
+	if count != 0 {
+		t.Errorf("week 1 rows = %d, want 0", count)
+	}
+
+	// Every later week matches a full recompute.
+	var incremental []database.TeamWeekResult
+	u.DB.Order("year, week, team_id, division").Find(&incremental)
+	if err := u.UpdateAllRankings(t.Context(), 0, 0); err != nil {
+		t.Fatalf("UpdateAllRankings: %v", err)
+	}
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+	var full []database.TeamWeekResult
+	u.DB.Where("week <> ? or year <> ?", 1, 2023).Order("year, week, team_id, division").Find(&full)
+	if len(incremental) == 0 || len(incremental) != len(full) {
+		t.Fatalf("incremental rows = %d, full rows = %d", len(incremental), len(full))
+	}
+	for i := range full {
+		if incremental[i] != full[i] {
+			t.Errorf("%d/%d team %d = %+v, want %+v",
+				full[i].Year, full[i].Week, full[i].TeamID, incremental[i], full[i])
+		}
+	}
+	if incremental[len(incremental)-1].Year != 2024 {
+		t.Error("2024 preseason ranking missing")
+	}
+}
+
+func TestRankingForWeek_Conferences(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+
+	var confs []database.ConferenceWeekResult
+	if err := u.DB.Order("division, rank").Find(&confs).Error; err != nil {
+		t.Fatalf("query conferences: %v", err)
+	}
+	if len(confs) != 3 {
+		t.Fatalf("len(confs) = %d, want 3", len(confs))
+	}
+	for _, conf := range confs {
+		if conf.Teams != 2 {
+			t.Errorf("%s teams = %d, want 2", conf.Conf, conf.Teams)
+		}
+		if fcs := conf.Conf == "MVFC"; (conf.Division == database.DivisionFCS) != fcs {
+			t.Errorf("%s division = %q", conf.Conf, conf.Division)
+		}
+		if conf.Fbs != conf.Division.IsFBS() {
+			t.Errorf("%s fbs = %v, want it to match division %q", conf.Conf, conf.Fbs, conf.Division)
+		}
+	}
+	// Each division ranks its own conferences.
+	if confs[0].Rank != 1 || confs[1].Rank != 2 || confs[2].Rank != 1 {
+		t.Errorf("ranks = %d, %d, %d, want 1, 2, 1", confs[0].Rank, confs[1].Rank, confs[2].Rank)
+	}
+	// The only FBS non-conference games are SEC wins over the Big Ten.
+	if confs[0].Conf != "SEC" || confs[0].NonConfWins != 2 || confs[0].NonConfLosses != 0 {
+		t.Errorf("top conference = %s %d-%d, want SEC 2-0",
+			confs[0].Conf, confs[0].NonConfWins, confs[0].NonConfLosses)
+	}
+}
+
+func TestInsertRankingsToDB_ConferenceDivisions(t *testing.T) {
+	u := newTestUpdater(t, nil)
+
+	// Both divisions have independents, ranked separately.
+	rankings := weekRankings{
+		teams: []database.TeamWeekResult{{TeamID: 1, Year: 2023, Week: 2, Sport: "ncaaf", Model: ranking.DefaultModel}},
+	}
+	for _, division := range []database.Division{database.DivisionFBS, database.DivisionFCS} {
+		rankings.conferences = append(rankings.conferences, database.ConferenceWeekResult{
+			Conf: "Independent", Year: 2023, Week: 2, Sport: "ncaaf", Model: ranking.DefaultModel,
+			Division: division, Teams: 3, Rank: 4,
+		})
+	}
+	if err := u.insertRankingsToDB(t.Context(), rankings); err != nil {
+		t.Fatalf("insertRankingsToDB: %v", err)
+	}
+
+	var confs []database.ConferenceWeekResult
+	if err := u.DB.Order("division").Find(&confs).Error; err != nil {
+		t.Fatalf("query conferences: %v", err)
+	}
+	if len(confs) != 2 || confs[0].Division != database.DivisionFBS || confs[1].Division != database.DivisionFCS {
+		t.Errorf("conferences = %+v, want an FBS and an FCS Independent row", confs)
+	}
+}
+
+func TestRankingForWeek_Unified(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+	u.Unified = true
+
+	// Epsilon (FCS) beats Delta (FBS), linking the two divisions.
+	cross := database.Game{
+		GameID: 501003, Season: 2023, Week: 2, HomeID: 4, AwayID: 5, HomeScore: 20, AwayScore: 24,
+		Sport: "ncaaf", StartTime: time.Date(2023, 9, 9, 20, 0, 0, 0, time.UTC),
+	}
+	if err := u.DB.Create(&cross).Error; err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+
+	var results []database.TeamWeekResult
+	if err := u.DB.Find(&results).Error; err != nil {
+		t.Fatalf("query results: %v", err)
+	}
+	if len(results) != 6 {
+		t.Fatalf("len(results) = %d, want 6", len(results))
+	}
+	byTeam := map[int64]database.TeamWeekResult{}
+	for _, result := range results {
+		byTeam[result.TeamID] = result
+		if fbs := result.TeamID <= 4; (result.Division == database.DivisionFBS) != fbs {
+			t.Errorf("team %d division = %q", result.TeamID, result.Division)
+		}
+		if result.OverallRank < 1 || result.OverallRank > 6 {
+			t.Errorf("team %d overall rank = %d, want [1,6]", result.TeamID, result.OverallRank)
+		}
+	}
+	// Ranks stay per division, and the win carries Epsilon past Delta overall.
+	if byTeam[1].FinalRank != 1 || byTeam[5].FinalRank != 1 || byTeam[6].FinalRank != 2 {
+		t.Errorf("division ranks = Alpha %d, Epsilon %d, Zeta %d, want 1, 1, 2",
+			byTeam[1].FinalRank, byTeam[5].FinalRank, byTeam[6].FinalRank)
+	}
+	if byTeam[5].OverallRank >= byTeam[4].OverallRank {
+		t.Errorf("Epsilon overall rank = %d, want above Delta's %d", byTeam[5].OverallRank, byTeam[4].OverallRank)
+	}
+
+	var confs []database.ConferenceWeekResult
+	if err := u.DB.Order("division, rank").Find(&confs).Error; err != nil {
+		t.Fatalf("query conferences: %v", err)
+	}
+	if len(confs) != 3 || confs[0].Rank != 1 || confs[1].Rank != 2 || confs[2].Conf != "MVFC" || confs[2].Rank != 1 {
+		t.Errorf("conferences = %+v, want SEC and Big Ten ranked 1-2, MVFC ranked 1", confs)
+	}
+}
+
+func TestRankingForWeek_DefaultModel(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+
+	var models []string
+	if err := u.DB.Model(&database.TeamWeekResult{}).Distinct("model").Pluck("model", &models).Error; err != nil {
+		t.Fatalf("query models: %v", err)
+	}
+	if len(models) != 1 || models[0] != ranking.DefaultModel {
+		t.Errorf("stored models = %v, want [%s]", models, ranking.DefaultModel)
+	}
+}
+
+func TestRankingForWeek_Uncertainty(t *testing.T) {
+	u := newTestUpdater(t, nil)
+	seedTeamsAndSeasons(t, u.DB)
+	seedGames(t, u.DB)
+	u.Models = []string{ranking.DefaultModel, "colley"}
+	u.Resamples = 50
+
+	if err := u.UpdateRecentRankings(t.Context()); err != nil {
+		t.Fatalf("UpdateRecentRankings: %v", err)
+	}
+
+	var results []database.TeamWeekResult
+	if err := u.DB.Where("division = ?", database.DivisionFBS).Find(&results).Error; err != nil {
+		t.Fatalf("query results: %v", err)
+	}
+	for _, r := range results {
+		// Only the composite model is resampled.
+		if ranged := r.RankBest > 0 && r.RankBest <= r.RankWorst; ranged != (r.Model == ranking.DefaultModel) {
+			t.Errorf("%s team %d: rank range %d-%d", r.Model, r.TeamID, r.RankBest, r.RankWorst)
+		}
+	}
+}
+
 // newTestURLs is a helper that overrides ESPN URLs for a given test server base URL.
 func newTestURLs(t *testing.T, serverURL string) func() {
 	t.Helper()